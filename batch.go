@@ -0,0 +1,515 @@
+package sheets
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+const defaultBatchMaxRequests = 500
+
+// Batch accumulates operations on a spreadsheet and sends them with as few
+// batchUpdate calls as possible when committed:
+//
+//	_, err := ss.NewBatch().
+//		AddSheet("Report").
+//		Format(SheetRange{SheetName: "Report", Range: header}, bold, "userEnteredFormat.textFormat").
+//		Merge(SheetRange{SheetName: "Report", Range: title}, MergeAll).
+//		Commit()
+//
+// Sheets are referenced by title, including sheets added or renamed earlier
+// in the same batch. Errors, such as references to unknown sheets, are
+// reported by Commit.
+type Batch struct {
+	spreadsheet *Spreadsheet
+
+	// MaxRequestsPerCall and MaxBytesPerCall cap the size of a single
+	// batchUpdate call. Larger batches are split over several calls.
+	MaxRequestsPerCall int
+	MaxBytesPerCall    int
+
+	// Requests of each operation added to the batch
+	ops [][]*sheets.Request
+
+	// Sheets added, renamed or deleted in the batch, by lowercase title
+	pending map[string]int64
+	deleted map[string]bool
+	// Named ranges added in the batch, by lowercase name
+	named map[string]*sheets.GridRange
+	// Number of sheets once the batch's operations so far are applied
+	sheetCount int
+
+	err error
+}
+
+func (s *Spreadsheet) NewBatch() *Batch {
+	return &Batch{
+		spreadsheet: s,
+
+		MaxRequestsPerCall: defaultBatchMaxRequests,
+		MaxBytesPerCall:    defaultChunkMaxBytes,
+
+		pending: map[string]int64{},
+		deleted: map[string]bool{},
+		named:   map[string]*sheets.GridRange{},
+
+		sheetCount: len(s.Sheets),
+	}
+}
+
+// Len returns the number of operations in the batch. The result of the next
+// operation added to the batch is BatchResult.Operations[Len()].
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Add adds raw requests to the batch, as a single operation.
+func (b *Batch) Add(requests ...*sheets.Request) *Batch {
+	b.ops = append(b.ops, requests)
+	return b
+}
+
+// AddSheet adds a new sheet. Its ID is chosen when the operation is added so
+// that later operations in the batch can reference it.
+func (b *Batch) AddSheet(title string) *Batch {
+	if _, ok := b.sheetID(title); ok {
+		return b.fail(errors.Errorf("sheet %s already exists", title))
+	}
+
+	id := b.newSheetID()
+	b.pending[strings.ToLower(title)] = id
+	delete(b.deleted, strings.ToLower(title))
+	b.sheetCount++
+
+	return b.Add(&sheets.Request{
+		AddSheet: &sheets.AddSheetRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId: id,
+				Title:   title,
+			},
+		},
+	})
+}
+
+// DuplicateSheet copies the sheet to a new sheet after the existing ones.
+func (b *Batch) DuplicateSheet(title, newTitle string) *Batch {
+	sourceID, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+	if _, ok := b.sheetID(newTitle); ok {
+		return b.fail(errors.Errorf("sheet %s already exists", newTitle))
+	}
+
+	id := b.newSheetID()
+	b.pending[strings.ToLower(newTitle)] = id
+	delete(b.deleted, strings.ToLower(newTitle))
+	index := b.sheetCount
+	b.sheetCount++
+
+	return b.Add(&sheets.Request{
+		DuplicateSheet: &sheets.DuplicateSheetRequest{
+			SourceSheetId:    sourceID,
+			NewSheetId:       id,
+			NewSheetName:     newTitle,
+			InsertSheetIndex: int64(index),
+		},
+	})
+}
+
+func (b *Batch) DeleteSheet(title string) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	delete(b.pending, strings.ToLower(title))
+	b.deleted[strings.ToLower(title)] = true
+	b.sheetCount--
+
+	return b.Add(&sheets.Request{
+		DeleteSheet: &sheets.DeleteSheetRequest{SheetId: id},
+	})
+}
+
+func (b *Batch) RenameSheet(title, newTitle string) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	delete(b.pending, strings.ToLower(title))
+	b.deleted[strings.ToLower(title)] = true
+	b.pending[strings.ToLower(newTitle)] = id
+	delete(b.deleted, strings.ToLower(newTitle))
+
+	return b.Add(&sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: id, Title: newTitle},
+			Fields:     "title",
+		},
+	})
+}
+
+// ResizeSheet sets the size of the sheet's grid.
+func (b *Batch) ResizeSheet(title string, rows, cols int) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(&sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId: id,
+				GridProperties: &sheets.GridProperties{
+					RowCount:    int64(rows),
+					ColumnCount: int64(cols),
+				},
+			},
+			Fields: "gridProperties.rowCount,gridProperties.columnCount",
+		},
+	})
+}
+
+// Format applies format to every cell of r. fields lists the parts of the
+// format to update, e.g. "userEnteredFormat.textFormat.bold", and defaults
+// to the whole "userEnteredFormat".
+func (b *Batch) Format(r SheetRange, format *sheets.CellFormat, fields ...string) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	mask := "userEnteredFormat"
+	if len(fields) > 0 {
+		mask = strings.Join(fields, ",")
+	}
+
+	return b.Add(&sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range:  gridRange,
+			Cell:   &sheets.CellData{UserEnteredFormat: format},
+			Fields: mask,
+		},
+	})
+}
+
+func (b *Batch) Merge(r SheetRange, mergeType MergeType) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(mergeRequest(gridRange, mergeType))
+}
+
+func (b *Batch) Unmerge(r SheetRange) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(unmergeRequest(gridRange))
+}
+
+func (b *Batch) InsertDimension(title string, dim Dimension, start, count int, inheritFromBefore bool) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(insertDimensionRequest(id, dim, start, count, inheritFromBefore))
+}
+
+func (b *Batch) DeleteDimension(title string, dim Dimension, start, count int) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(deleteDimensionRequest(id, dim, start, count))
+}
+
+func (b *Batch) MoveDimension(title string, dim Dimension, start, count, destination int) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(moveDimensionRequest(id, dim, start, count, destination))
+}
+
+// Protect protects r so that only editors, given by email, can edit it.
+func (b *Batch) Protect(r SheetRange, description string, editors ...string) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(&sheets.Request{
+		AddProtectedRange: &sheets.AddProtectedRangeRequest{
+			ProtectedRange: &sheets.ProtectedRange{
+				Range:       gridRange,
+				Description: description,
+				Editors:     &sheets.Editors{Users: editors},
+			},
+		},
+	})
+}
+
+func (b *Batch) AddNamedRange(name string, r SheetRange) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+	b.named[strings.ToLower(name)] = gridRange
+
+	return b.Add(&sheets.Request{
+		AddNamedRange: &sheets.AddNamedRangeRequest{
+			NamedRange: &sheets.NamedRange{
+				Name:  name,
+				Range: gridRange,
+			},
+		},
+	})
+}
+
+// OperationResult is the outcome of an operation of a batch. Only the fields
+// relevant to the operation are set.
+type OperationResult struct {
+	// Replies to the operation's requests, in order
+	Replies []*sheets.Response
+
+	NewSheet         *sheets.SheetProperties
+	DuplicateSheet   *sheets.SheetProperties
+	ProtectedRangeID int64
+	NamedRangeID     string
+	ChartID          int64
+	FilterViewID     int64
+	FindReplace      *FindReplaceResult
+}
+
+func newOperationResult(replies []*sheets.Response) *OperationResult {
+	res := &OperationResult{Replies: replies}
+
+	for _, reply := range replies {
+		if reply == nil {
+			continue
+		}
+
+		switch {
+		case reply.AddSheet != nil:
+			res.NewSheet = reply.AddSheet.Properties
+		case reply.DuplicateSheet != nil:
+			res.DuplicateSheet = reply.DuplicateSheet.Properties
+		case reply.AddProtectedRange != nil && reply.AddProtectedRange.ProtectedRange != nil:
+			res.ProtectedRangeID = reply.AddProtectedRange.ProtectedRange.ProtectedRangeId
+		case reply.AddNamedRange != nil && reply.AddNamedRange.NamedRange != nil:
+			res.NamedRangeID = reply.AddNamedRange.NamedRange.NamedRangeId
+		case reply.AddChart != nil && reply.AddChart.Chart != nil:
+			res.ChartID = reply.AddChart.Chart.ChartId
+		case reply.AddFilterView != nil && reply.AddFilterView.Filter != nil:
+			res.FilterViewID = reply.AddFilterView.Filter.FilterViewId
+		case reply.DuplicateFilterView != nil && reply.DuplicateFilterView.Filter != nil:
+			res.FilterViewID = reply.DuplicateFilterView.Filter.FilterViewId
+		case reply.FindReplace != nil:
+			res.FindReplace = findReplaceResultFromAPI(reply.FindReplace)
+		}
+	}
+
+	return res
+}
+
+// BatchResult holds the results of a committed batch, with one entry per
+// operation in the order they were added to the batch.
+type BatchResult struct {
+	Operations []*OperationResult
+}
+
+// NewSheet returns the properties of the sheet titled title that was added
+// or duplicated by the batch, or nil.
+func (r *BatchResult) NewSheet(title string) *sheets.SheetProperties {
+	for _, op := range r.Operations {
+		for _, props := range []*sheets.SheetProperties{op.NewSheet, op.DuplicateSheet} {
+			if props != nil && strings.EqualFold(props.Title, title) {
+				return props
+			}
+		}
+	}
+
+	return nil
+}
+
+// Commit sends the batch's operations, split over as many batchUpdate calls
+// as needed. An operation's requests are always sent in the same call.
+//
+// Splitting isn't atomic: if a call fails, the operations of the calls that
+// succeeded stay applied. Their results are returned along with the error, and
+// they are removed from the batch so that calling Commit again only sends the
+// remaining operations.
+func (b *Batch) Commit() (*BatchResult, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	calls, err := splitOperations(b.ops, b.MaxRequestsPerCall, b.MaxBytesPerCall)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{}
+	for _, call := range calls {
+		var requests []*sheets.Request
+		for _, op := range call {
+			requests = append(requests, op...)
+		}
+
+		var replies []*sheets.Response
+		if len(requests) > 0 {
+			first := len(result.Operations)
+			resp, err := b.spreadsheet.DoBatch(requests...)
+			if err != nil {
+				b.ops = b.ops[first:]
+				return result, errors.Wrapf(err, "couldn't commit operations %d to %d", first, first+len(call)-1)
+			}
+			replies = resp.Replies
+		}
+
+		for _, op := range call {
+			n := len(op)
+			if n > len(replies) {
+				n = len(replies)
+			}
+			result.Operations = append(result.Operations, newOperationResult(replies[:n]))
+			replies = replies[n:]
+		}
+	}
+
+	b.ops = nil
+
+	return result, nil
+}
+
+func (b *Batch) fail(err error) *Batch {
+	if b.err == nil {
+		b.err = err
+	}
+
+	return b
+}
+
+func (b *Batch) unknownSheet(title string) *Batch {
+	return b.fail(errors.Errorf("sheet %s does not exist", title))
+}
+
+func (b *Batch) sheetID(title string) (int64, bool) {
+	query := strings.ToLower(title)
+	if id, ok := b.pending[query]; ok {
+		return id, true
+	}
+	if b.deleted[query] {
+		return 0, false
+	}
+
+	sheet := b.spreadsheet.GetSheet(title)
+	if sheet == nil {
+		return 0, false
+	}
+
+	return sheet.Properties.SheetId, true
+}
+
+// gridRange resolves r, which may be a named range added earlier in the
+// batch.
+func (b *Batch) gridRange(r SheetRange) (*sheets.GridRange, error) {
+	if r.Name != "" {
+		if gridRange, ok := b.named[strings.ToLower(r.Name)]; ok {
+			return gridRange, nil
+		}
+
+		named := b.spreadsheet.namedRange(r.Name)
+		if named == nil {
+			return nil, errors.Errorf("named range %s does not exist", r.Name)
+		}
+
+		return named.Range, nil
+	}
+
+	id, ok := b.sheetID(r.SheetName)
+	if !ok {
+		return nil, errors.Errorf("sheet %s does not exist", r.SheetName)
+	}
+
+	return r.Range.GridRange(id), nil
+}
+
+// sheetIDs picks the IDs of sheets added by batches. It's seeded so that
+// processes don't pick the same IDs.
+var sheetIDs = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+func (b *Batch) newSheetID() int64 {
+	for {
+		sheetIDs.Lock()
+		id := int64(sheetIDs.Int31())
+		sheetIDs.Unlock()
+
+		used := false
+		for _, sheet := range b.spreadsheet.Sheets {
+			if sheet.Properties.SheetId == id {
+				used = true
+			}
+		}
+		for _, pending := range b.pending {
+			if pending == id {
+				used = true
+			}
+		}
+
+		if !used && id != 0 {
+			return id
+		}
+	}
+}
+
+// splitOperations groups operations in calls of at most maxRequests requests
+// and about maxBytes of JSON. Operations are never split, so a call may exceed
+// the limits if a single operation does.
+func splitOperations(ops [][]*sheets.Request, maxRequests, maxBytes int) ([][][]*sheets.Request, error) {
+	var (
+		calls    [][][]*sheets.Request
+		cur      [][]*sheets.Request
+		requests int
+		size     int
+	)
+
+	for i, op := range ops {
+		encoded, err := json.Marshal(op)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't encode operation %d", i)
+		}
+
+		if len(cur) > 0 && ((maxRequests > 0 && requests+len(op) > maxRequests) || (maxBytes > 0 && size+len(encoded) > maxBytes)) {
+			calls = append(calls, cur)
+			cur, requests, size = nil, 0, 0
+		}
+
+		cur = append(cur, op)
+		requests += len(op)
+		size += len(encoded)
+	}
+
+	if len(cur) > 0 {
+		calls = append(calls, cur)
+	}
+
+	return calls, nil
+}
@@ -0,0 +1,199 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func testSpreadsheet(client *Client) *Spreadsheet {
+	return &Spreadsheet{client, &sheets.Spreadsheet{
+		SpreadsheetId: "ssid",
+		Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{SheetId: 0, Title: "Sheet1"}},
+			{Properties: &sheets.SheetProperties{SheetId: 7, Index: 1, Title: "Data"}},
+		},
+	}}
+}
+
+func TestBatchResolvesSheets(t *testing.T) {
+	ss := testSpreadsheet(nil)
+	r := CellRange{CellPos{0, 0}, CellPos{0, 3}}
+
+	b := ss.NewBatch().
+		AddSheet("Report").
+		Format(SheetRange{SheetName: "report", Range: r}, &sheets.CellFormat{}).
+		RenameSheet("Data", "Raw").
+		Merge(SheetRange{SheetName: "Raw", Range: r}, MergeAll)
+
+	if b.err != nil {
+		t.Fatalf("Unexpected error: %v", b.err)
+	}
+	if b.Len() != 4 {
+		t.Fatalf("Wanted 4 operations, but got %d", b.Len())
+	}
+
+	newID := b.ops[0][0].AddSheet.Properties.SheetId
+	if newID == 0 || newID == 7 {
+		t.Errorf("Expected a fresh sheet ID, but got %d", newID)
+	}
+	if got := b.ops[1][0].RepeatCell.Range.SheetId; got != newID {
+		t.Errorf("Wanted format on sheet %d, but got %d", newID, got)
+	}
+	if got := b.ops[3][0].MergeCells.Range.SheetId; got != 7 {
+		t.Errorf("Wanted merge on sheet 7, but got %d", got)
+	}
+
+	b.Unmerge(SheetRange{SheetName: "Data", Range: r})
+	if b.err == nil {
+		t.Error("Expected error for renamed sheet, but got none")
+	}
+	if _, err := b.Commit(); err == nil {
+		t.Error("Expected Commit to report the error, but got none")
+	}
+}
+
+func TestBatchCommitSplitsCalls(t *testing.T) {
+	var calls []int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		calls = append(calls, len(req.Requests))
+
+		resp := &sheets.BatchUpdateSpreadsheetResponse{
+			UpdatedSpreadsheet: testSpreadsheet(nil).Spreadsheet,
+		}
+		for range req.Requests {
+			resp.Replies = append(resp.Replies, &sheets.Response{})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	b := testSpreadsheet(client).NewBatch()
+	b.MaxRequestsPerCall = 2
+	for i := 0; i < 5; i++ {
+		b.DeleteDimension("Data", DimensionRows, i, 1)
+	}
+
+	res, err := b.Commit()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(res.Operations) != 5 {
+		t.Errorf("Wanted 5 results, but got %d", len(res.Operations))
+	}
+	if len(calls) != 3 || calls[0] != 2 || calls[1] != 2 || calls[2] != 1 {
+		t.Errorf("Wanted calls of 2, 2 and 1 requests, but got %v", calls)
+	}
+}
+
+func TestBatchCommitKeepsFailedOperations(t *testing.T) {
+	var (
+		calls []int
+		fail  = true
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		calls = append(calls, len(req.Requests))
+
+		// The second call fails the first time
+		if len(calls) == 2 && fail {
+			fail = false
+			http.Error(w, `{"error": {"code": 400, "message": "invalid"}}`, http.StatusBadRequest)
+			return
+		}
+
+		resp := &sheets.BatchUpdateSpreadsheetResponse{
+			UpdatedSpreadsheet: testSpreadsheet(nil).Spreadsheet,
+		}
+		for range req.Requests {
+			resp.Replies = append(resp.Replies, &sheets.Response{})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	b := testSpreadsheet(client).NewBatch()
+	b.MaxRequestsPerCall = 2
+	for i := 0; i < 5; i++ {
+		b.DeleteDimension("Data", DimensionRows, i, 1)
+	}
+
+	res, err := b.Commit()
+	if err == nil {
+		t.Fatal("Expected error, but got none")
+	}
+	if len(res.Operations) != 2 {
+		t.Errorf("Wanted 2 results, but got %d", len(res.Operations))
+	}
+	if b.Len() != 3 {
+		t.Fatalf("Wanted 3 operations left, but got %d", b.Len())
+	}
+
+	res, err = b.Commit()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(res.Operations) != 3 {
+		t.Errorf("Wanted 3 results, but got %d", len(res.Operations))
+	}
+
+	expected := []int{2, 2, 2, 1}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Wanted calls of %v requests, but got %v", expected, calls)
+	}
+}
+
+func TestBatchCommitTypedResults(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{
+			UpdatedSpreadsheet: testSpreadsheet(nil).Spreadsheet,
+			Replies: []*sheets.Response{
+				{AddSheet: &sheets.AddSheetResponse{Properties: req.Requests[0].AddSheet.Properties}},
+				{},
+				{},
+				{AddProtectedRange: &sheets.AddProtectedRangeResponse{
+					ProtectedRange: &sheets.ProtectedRange{ProtectedRangeId: 12},
+				}},
+				{DuplicateSheet: &sheets.DuplicateSheetResponse{
+					Properties: &sheets.SheetProperties{SheetId: 99, Title: "Copy"},
+				}},
+			},
+		})
+	}))
+
+	r := SheetRange{SheetName: "Data", Range: CellRange{CellPos{0, 0}, CellPos{1, 1}}}
+	b := testSpreadsheet(client).NewBatch().
+		AddSheet("Report").
+		Add(&sheets.Request{}, &sheets.Request{})
+	protect := b.Len()
+	b.Protect(r, "header").DuplicateSheet("Data", "Copy")
+
+	res, err := b.Commit()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(res.Operations) != 4 {
+		t.Fatalf("Wanted 4 results, but got %d", len(res.Operations))
+	}
+	if props := res.NewSheet("report"); props == nil || props.SheetId != res.Operations[0].NewSheet.SheetId {
+		t.Errorf("Unexpected new sheet %+v", props)
+	}
+	if len(res.Operations[1].Replies) != 2 {
+		t.Errorf("Wanted 2 replies for the raw operation, but got %d", len(res.Operations[1].Replies))
+	}
+	if id := res.Operations[protect].ProtectedRangeID; id != 12 {
+		t.Errorf("Wanted protected range 12, but got %d", id)
+	}
+	if props := res.NewSheet("Copy"); props == nil || props.SheetId != 99 {
+		t.Errorf("Unexpected duplicate sheet %+v", props)
+	}
+}
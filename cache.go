@@ -0,0 +1,429 @@
+package sheets
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Refresh fetches the latest metadata of the spreadsheet. Sheets previously
+// returned by GetSheet pick it up on their next operation.
+func (s *Spreadsheet) Refresh() error {
+	current, err := s.Client.GetSpreadsheet(s.Id())
+	if err != nil {
+		return errors.Wrapf(err, "couldn't refresh spreadsheet %s", s.Id())
+	}
+
+	s.Client.setStale(s.Spreadsheet, false)
+	s.Spreadsheet = current.Spreadsheet
+
+	return nil
+}
+
+// Stale reports whether the cached metadata is known to be out of date,
+// after batches sent with Client.LightweightBatches had effects that couldn't
+// be applied locally. Getters such as GetSheet only read the cached metadata:
+// call Refresh to bring it up to date. Otherwise the next batch asks for the
+// whole updated spreadsheet in its response.
+func (s *Spreadsheet) Stale() bool {
+	return s.Client != nil && s.Client.isStale(s.Spreadsheet)
+}
+
+// refreshIfStale refreshes the spreadsheet if it's stale.
+func (s *Spreadsheet) refreshIfStale() error {
+	if !s.Stale() {
+		return nil
+	}
+
+	return s.Refresh()
+}
+
+// applyLocally applies the known effects of requests to the cached
+// spreadsheet, and marks it stale if some effects aren't known.
+func (s *Spreadsheet) applyLocally(requests []*sheets.Request, replies []*sheets.Response) {
+	for i, req := range requests {
+		var reply *sheets.Response
+		if i < len(replies) {
+			reply = replies[i]
+		}
+
+		if !s.applyRequest(req, reply) {
+			s.Client.setStale(s.Spreadsheet, true)
+		}
+	}
+}
+
+// applyRequest applies a single request, and reports whether the cached
+// spreadsheet is still accurate.
+func (s *Spreadsheet) applyRequest(req *sheets.Request, reply *sheets.Response) bool {
+	switch {
+	case req.AddSheet != nil:
+		if reply == nil || reply.AddSheet == nil {
+			return false
+		}
+		s.insertSheet(&sheets.Sheet{Properties: reply.AddSheet.Properties})
+		return true
+
+	case req.DuplicateSheet != nil:
+		if reply == nil || reply.DuplicateSheet == nil {
+			return false
+		}
+		s.insertSheet(&sheets.Sheet{Properties: reply.DuplicateSheet.Properties})
+		// The copy's merges, protected ranges, etc. are unknown
+		return false
+
+	case req.DeleteSheet != nil:
+		for i, sheet := range s.Sheets {
+			if sheet.Properties.SheetId == req.DeleteSheet.SheetId {
+				s.Sheets = append(s.Sheets[:i:i], s.Sheets[i+1:]...)
+				s.reindexSheets()
+				return true
+			}
+		}
+		return false
+
+	case req.UpdateSheetProperties != nil:
+		return s.applySheetProperties(req.UpdateSheetProperties)
+
+	case req.AppendDimension != nil:
+		sheet := s.sheetByID(req.AppendDimension.SheetId)
+		return sheet != nil && resizeGrid(sheet, req.AppendDimension.Dimension, req.AppendDimension.Length)
+
+	case req.InsertDimension != nil:
+		rng := req.InsertDimension.Range
+		sheet := s.sheetByID(rng.SheetId)
+		if sheet == nil || !resizeGrid(sheet, rng.Dimension, rng.EndIndex-rng.StartIndex) {
+			return false
+		}
+
+		// The grid is still accurate, but ranges after the new ones shift
+		return !hasRangeMetadata(s, sheet)
+
+	case req.DeleteDimension != nil:
+		rng := req.DeleteDimension.Range
+		sheet := s.sheetByID(rng.SheetId)
+		if sheet == nil || !resizeGrid(sheet, rng.Dimension, rng.StartIndex-rng.EndIndex) {
+			return false
+		}
+
+		return !hasRangeMetadata(s, sheet)
+
+	case req.MoveDimension != nil:
+		sheet := s.sheetByID(req.MoveDimension.Source.SheetId)
+		return sheet != nil && !hasRangeMetadata(s, sheet)
+
+	case req.MergeCells != nil:
+		sheet := s.sheetByID(req.MergeCells.Range.SheetId)
+		// Merging discards the values of the hidden cells
+		return sheet != nil && applyMerge(sheet, req.MergeCells) && !hasGridData(s)
+
+	case req.UnmergeCells != nil:
+		sheet := s.sheetByID(req.UnmergeCells.Range.SheetId)
+		return sheet != nil && applyUnmerge(sheet, req.UnmergeCells)
+
+	case req.SetBasicFilter != nil:
+		sheet := s.sheetByID(req.SetBasicFilter.Filter.Range.SheetId)
+		if sheet == nil {
+			return false
+		}
+		sheet.BasicFilter = req.SetBasicFilter.Filter
+		return true
+
+	case req.ClearBasicFilter != nil:
+		sheet := s.sheetByID(req.ClearBasicFilter.SheetId)
+		if sheet == nil {
+			return false
+		}
+		sheet.BasicFilter = nil
+		return true
+
+	case req.AddFilterView != nil:
+		if reply == nil || reply.AddFilterView == nil || reply.AddFilterView.Filter == nil ||
+			reply.AddFilterView.Filter.Range == nil {
+			return false
+		}
+		sheet := s.sheetByID(reply.AddFilterView.Filter.Range.SheetId)
+		if sheet == nil {
+			return false
+		}
+		sheet.FilterViews = append(sheet.FilterViews, reply.AddFilterView.Filter)
+		return true
+
+	case req.DeleteFilterView != nil:
+		for _, sheet := range s.Sheets {
+			for i, view := range sheet.FilterViews {
+				if view.FilterViewId == req.DeleteFilterView.FilterId {
+					sheet.FilterViews = append(sheet.FilterViews[:i:i], sheet.FilterViews[i+1:]...)
+					return true
+				}
+			}
+		}
+		return false
+
+	case req.AddChart != nil:
+		if reply == nil || reply.AddChart == nil || reply.AddChart.Chart == nil ||
+			reply.AddChart.Chart.Position == nil {
+			return false
+		}
+		// Charts on a new sheet also add the sheet, which isn't known
+		overlay := reply.AddChart.Chart.Position.OverlayPosition
+		if overlay == nil || overlay.AnchorCell == nil {
+			return false
+		}
+		sheet := s.sheetByID(overlay.AnchorCell.SheetId)
+		if sheet == nil {
+			return false
+		}
+		sheet.Charts = append(sheet.Charts, reply.AddChart.Chart)
+		return true
+
+	case req.UpdateChartSpec != nil:
+		chart := s.chartByID(req.UpdateChartSpec.ChartId)
+		if chart == nil {
+			return false
+		}
+		chart.Spec = req.UpdateChartSpec.Spec
+		return true
+
+	case req.DeleteEmbeddedObject != nil:
+		for _, sheet := range s.Sheets {
+			for i, chart := range sheet.Charts {
+				if chart.ChartId == req.DeleteEmbeddedObject.ObjectId {
+					sheet.Charts = append(sheet.Charts[:i:i], sheet.Charts[i+1:]...)
+					return true
+				}
+			}
+		}
+		return false
+
+	case req.AddNamedRange != nil:
+		if reply == nil || reply.AddNamedRange == nil || reply.AddNamedRange.NamedRange == nil {
+			return false
+		}
+		s.NamedRanges = append(s.NamedRanges, reply.AddNamedRange.NamedRange)
+		return true
+
+	case req.DeleteNamedRange != nil:
+		for i, named := range s.NamedRanges {
+			if named.NamedRangeId == req.DeleteNamedRange.NamedRangeId {
+				s.NamedRanges = append(s.NamedRanges[:i:i], s.NamedRanges[i+1:]...)
+				return true
+			}
+		}
+		return false
+
+	case req.RepeatCell != nil, req.UpdateCells != nil, req.UpdateBorders != nil,
+		req.SortRange != nil, req.FindReplace != nil, req.CopyPaste != nil,
+		req.PasteData != nil, req.AutoResizeDimensions != nil:
+		// These only change cell data, which is cached only when fetched
+		// with GetSpreadsheetWithData
+		return !hasGridData(s)
+	}
+
+	return false
+}
+
+func (s *Spreadsheet) sheetByID(id int64) *sheets.Sheet {
+	for _, sheet := range s.Sheets {
+		if sheet.Properties.SheetId == id {
+			return sheet
+		}
+	}
+
+	return nil
+}
+
+func (s *Spreadsheet) chartByID(id int64) *sheets.EmbeddedChart {
+	for _, sheet := range s.Sheets {
+		for _, chart := range sheet.Charts {
+			if chart.ChartId == id {
+				return chart
+			}
+		}
+	}
+
+	return nil
+}
+
+// insertSheet adds sheet at its index, shifting the sheets after it.
+func (s *Spreadsheet) insertSheet(sheet *sheets.Sheet) {
+	s.sortSheets()
+
+	index := int(sheet.Properties.Index)
+	if index > len(s.Sheets) {
+		index = len(s.Sheets)
+	}
+
+	s.Sheets = append(s.Sheets[:index:index], append([]*sheets.Sheet{sheet}, s.Sheets[index:]...)...)
+	s.reindexSheets()
+}
+
+func (s *Spreadsheet) sortSheets() {
+	sort.SliceStable(s.Sheets, func(i, j int) bool {
+		return s.Sheets[i].Properties.Index < s.Sheets[j].Properties.Index
+	})
+}
+
+func (s *Spreadsheet) reindexSheets() {
+	for i, sheet := range s.Sheets {
+		sheet.Properties.Index = int64(i)
+	}
+}
+
+var knownSheetPropertiesFields = map[string]bool{
+	"title":                            true,
+	"index":                            true,
+	"hidden":                           true,
+	"tabColor":                         true,
+	"rightToLeft":                      true,
+	"gridProperties.rowCount":          true,
+	"gridProperties.columnCount":       true,
+	"gridProperties.frozenRowCount":    true,
+	"gridProperties.frozenColumnCount": true,
+	"gridProperties.hideGridlines":     true,
+}
+
+func (s *Spreadsheet) applySheetProperties(req *sheets.UpdateSheetPropertiesRequest) bool {
+	sheet := s.sheetByID(req.Properties.SheetId)
+	if sheet == nil {
+		return false
+	}
+
+	fields := strings.Split(req.Fields, ",")
+	for _, field := range fields {
+		if !knownSheetPropertiesFields[strings.TrimSpace(field)] {
+			return false
+		}
+	}
+
+	props, update := sheet.Properties, req.Properties
+	updateGrid := update.GridProperties
+	if updateGrid == nil {
+		updateGrid = &sheets.GridProperties{}
+	}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if strings.HasPrefix(field, "gridProperties.") && props.GridProperties == nil {
+			props.GridProperties = &sheets.GridProperties{}
+		}
+
+		switch field {
+		case "title":
+			props.Title = update.Title
+		case "index":
+			s.moveSheet(sheet, int(update.Index))
+		case "hidden":
+			props.Hidden = update.Hidden
+		case "tabColor":
+			props.TabColor = update.TabColor
+		case "rightToLeft":
+			props.RightToLeft = update.RightToLeft
+		case "gridProperties.rowCount":
+			props.GridProperties.RowCount = updateGrid.RowCount
+		case "gridProperties.columnCount":
+			props.GridProperties.ColumnCount = updateGrid.ColumnCount
+		case "gridProperties.frozenRowCount":
+			props.GridProperties.FrozenRowCount = updateGrid.FrozenRowCount
+		case "gridProperties.frozenColumnCount":
+			props.GridProperties.FrozenColumnCount = updateGrid.FrozenColumnCount
+		case "gridProperties.hideGridlines":
+			props.GridProperties.HideGridlines = updateGrid.HideGridlines
+		}
+	}
+
+	return true
+}
+
+// moveSheet moves sheet to index, which like in the API is expressed in
+// positions before the move.
+func (s *Spreadsheet) moveSheet(sheet *sheets.Sheet, index int) {
+	s.sortSheets()
+
+	from := 0
+	for i := range s.Sheets {
+		if s.Sheets[i] == sheet {
+			from = i
+		}
+	}
+	if index > from {
+		index--
+	}
+
+	rest := append(s.Sheets[:from:from], s.Sheets[from+1:]...)
+	if index > len(rest) {
+		index = len(rest)
+	}
+
+	s.Sheets = append(rest[:index:index], append([]*sheets.Sheet{sheet}, rest[index:]...)...)
+	s.reindexSheets()
+}
+
+func resizeGrid(sheet *sheets.Sheet, dim string, delta int64) bool {
+	grid := sheet.Properties.GridProperties
+	if grid == nil {
+		return false
+	}
+
+	switch dim {
+	case string(DimensionRows):
+		grid.RowCount += delta
+	case string(DimensionColumns):
+		grid.ColumnCount += delta
+	default:
+		return false
+	}
+
+	return true
+}
+
+// hasRangeMetadata reports whether the cached sheet holds ranges that would
+// shift when rows or columns are inserted, deleted or moved.
+func hasRangeMetadata(s *Spreadsheet, sheet *sheets.Sheet) bool {
+	if len(sheet.Data) > 0 || len(sheet.Merges) > 0 || len(sheet.ProtectedRanges) > 0 ||
+		len(sheet.ConditionalFormats) > 0 || len(sheet.FilterViews) > 0 || sheet.BasicFilter != nil ||
+		len(sheet.Charts) > 0 || len(sheet.BandedRanges) > 0 || len(sheet.DeveloperMetadata) > 0 {
+		return true
+	}
+
+	for _, named := range s.NamedRanges {
+		if named.Range != nil && named.Range.SheetId == sheet.Properties.SheetId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasGridData(s *Spreadsheet) bool {
+	for _, sheet := range s.Sheets {
+		if len(sheet.Data) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Client) isStale(cached *sheets.Spreadsheet) bool {
+	c.staleMu.Lock()
+	defer c.staleMu.Unlock()
+
+	return c.stale[cached]
+}
+
+func (c *Client) setStale(cached *sheets.Spreadsheet, stale bool) {
+	c.staleMu.Lock()
+	defer c.staleMu.Unlock()
+
+	if !stale {
+		delete(c.stale, cached)
+		return
+	}
+
+	if c.stale == nil {
+		c.stale = map[*sheets.Spreadsheet]bool{}
+	}
+	c.stale[cached] = true
+}
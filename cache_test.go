@@ -0,0 +1,188 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func cacheTestSpreadsheet() *Spreadsheet {
+	return &Spreadsheet{Client: &Client{}, Spreadsheet: &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{SheetId: 1, Index: 0, Title: "A",
+				GridProperties: &sheets.GridProperties{RowCount: 1000, ColumnCount: 26}}},
+			{Properties: &sheets.SheetProperties{SheetId: 2, Index: 1, Title: "B"}},
+			{Properties: &sheets.SheetProperties{SheetId: 3, Index: 2, Title: "C"}},
+		},
+	}}
+}
+
+func sheetOrder(s *Spreadsheet) string {
+	order := ""
+	for i, sheet := range s.Sheets {
+		if int(sheet.Properties.Index) != i {
+			return "bad index"
+		}
+		order += sheet.Properties.Title
+	}
+
+	return order
+}
+
+func TestApplyLocally(t *testing.T) {
+	ss := cacheTestSpreadsheet()
+
+	ss.applyLocally([]*sheets.Request{
+		{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "D"}}},
+		{DeleteSheet: &sheets.DeleteSheetRequest{SheetId: 2}},
+		{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: 1, Title: "Renamed", Index: 3,
+				GridProperties: &sheets.GridProperties{FrozenRowCount: 1}},
+			Fields: "title,index,gridProperties.frozenRowCount",
+		}},
+		{InsertDimension: &sheets.InsertDimensionRequest{
+			Range: &sheets.DimensionRange{SheetId: 1, Dimension: "ROWS", StartIndex: 5, EndIndex: 15},
+		}},
+		{RepeatCell: &sheets.RepeatCellRequest{}},
+	}, []*sheets.Response{
+		{AddSheet: &sheets.AddSheetResponse{Properties: &sheets.SheetProperties{SheetId: 4, Index: 1, Title: "D"}}},
+		{}, {}, {}, {},
+	})
+
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+	if got := sheetOrder(ss); got != "DCRenamed" {
+		t.Errorf("Wanted sheets DCRenamed, but got %s", got)
+	}
+
+	grid := ss.sheetByID(1).Properties.GridProperties
+	if grid.RowCount != 1010 || grid.FrozenRowCount != 1 || grid.ColumnCount != 26 {
+		t.Errorf("Unexpected grid %+v", grid)
+	}
+
+	ss.applyLocally([]*sheets.Request{
+		{AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{}},
+	}, nil)
+	if !ss.Stale() {
+		t.Error("Expected the cache to be stale")
+	}
+}
+
+func TestResizeWithRangeMetadata(t *testing.T) {
+	ss := cacheTestSpreadsheet()
+	ss.sheetByID(1).Merges = []*sheets.GridRange{{SheetId: 1, StartRowIndex: 10, EndRowIndex: 12}}
+
+	ss.applyLocally([]*sheets.Request{
+		{DeleteDimension: &sheets.DeleteDimensionRequest{
+			Range: &sheets.DimensionRange{SheetId: 1, Dimension: "COLUMNS", StartIndex: 0, EndIndex: 6},
+		}},
+	}, nil)
+
+	// The merge shifted, but the grid size is known
+	if !ss.Stale() {
+		t.Error("Expected the cache to be stale")
+	}
+	if grid := ss.sheetByID(1).Properties.GridProperties; grid.ColumnCount != 20 {
+		t.Errorf("Wanted 20 columns, but got %d", grid.ColumnCount)
+	}
+}
+
+func TestStalenessPerCopy(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(testSpreadsheet(nil).Spreadsheet)
+	}))
+
+	first, second := testSpreadsheet(client), testSpreadsheet(client)
+	unknown := []*sheets.Request{{AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{}}}
+
+	first.applyLocally(unknown, nil)
+	second.applyLocally(unknown, nil)
+	if !first.Stale() || !second.Stale() {
+		t.Fatal("Expected both copies to be stale")
+	}
+
+	if err := second.Refresh(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if second.Stale() {
+		t.Error("Expected the refreshed copy to be up to date")
+	}
+	if !first.Stale() {
+		t.Error("Expected the other copy to still be stale")
+	}
+
+	fetched, err := client.GetSpreadsheet("ssid")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetched.Stale() {
+		t.Error("Expected a freshly fetched copy to be up to date")
+	}
+}
+
+func TestMoveSheet(t *testing.T) {
+	for _, tt := range []struct {
+		id       int64
+		index    int
+		expected string
+	}{
+		{1, 2, "BAC"},
+		{1, 3, "BCA"},
+		{3, 0, "CAB"},
+		{2, 1, "ABC"},
+	} {
+		ss := cacheTestSpreadsheet()
+		ss.moveSheet(ss.sheetByID(tt.id), tt.index)
+
+		if got := sheetOrder(ss); got != tt.expected {
+			t.Errorf("Wanted %s, but got %s for %+v", tt.expected, got, tt)
+		}
+	}
+}
+
+func TestStaleSpreadsheetRefreshedByBatch(t *testing.T) {
+	var included []bool
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		included = append(included, req.IncludeSpreadsheetInResponse)
+
+		resp := &sheets.BatchUpdateSpreadsheetResponse{Replies: []*sheets.Response{{}}}
+		if req.IncludeSpreadsheetInResponse {
+			resp.UpdatedSpreadsheet = testSpreadsheet(nil).Spreadsheet
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	client.LightweightBatches = true
+
+	ss := testSpreadsheet(client)
+	unknown := &sheets.Request{AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{}}
+
+	if _, err := ss.DoBatch(unknown); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ss.Stale() {
+		t.Error("Expected the cache to be stale")
+	}
+
+	// Getters don't refresh
+	if ss.GetSheet("Data") == nil || len(included) != 1 {
+		t.Errorf("Wanted 1 call, but got %d", len(included))
+	}
+
+	if _, err := ss.DoBatch(unknown); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+
+	expected := []bool{false, true}
+	if !reflect.DeepEqual(included, expected) {
+		t.Errorf("Wanted full responses %v, but got %v", expected, included)
+	}
+}
@@ -2,7 +2,10 @@ package sheets
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	sheets "google.golang.org/api/sheets/v4"
 )
 
 const (
@@ -33,15 +36,43 @@ func (a CellRange) String() string {
 	return fmt.Sprintf("%s:%s", a.Start.A1Notation(), a.End.A1Notation())
 }
 
+// SheetRange is a range of cells of a sheet, or a named range, see ByName.
+//
+// Name was added after SheetName and Range, so SheetRange literals must name
+// their fields, e.g. SheetRange{SheetName: "Data", Range: r}.
 type SheetRange struct {
 	SheetName string
 	Range     CellRange
+
+	// Name refers to a named range instead of SheetName and Range, see
+	// ByName
+	Name string
 }
 
 func (s SheetRange) String() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
 	return fmt.Sprintf("%s!%s", s.SheetName, s.Range.String())
 }
 
+// quotedA1 is like String, but quotes the sheet name so that names with
+// spaces or punctuation, or that look like cell references, can be used in
+// formulas.
+func (s SheetRange) quotedA1() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	return fmt.Sprintf("%s!%s", quoteSheetName(s.SheetName), s.Range.String())
+}
+
+// quoteSheetName quotes name for use in A1 notation, e.g. 'My sheet'.
+func quoteSheetName(name string) string {
+	return "'" + strings.Replace(name, "'", "''", -1) + "'"
+}
+
 func DefaultRange(data [][]string) CellRange {
 	bottomLeft := CellPos{len(data), len(data[0])}
 
@@ -78,3 +109,98 @@ func aRangeLetter(num int) string {
 
 	return strings.Join(digits, "")
 }
+
+// ParseCellPos parses a cell in A1 notation, e.g. "AB12".
+func ParseCellPos(a1 string) (CellPos, error) {
+	i := 0
+	col := 0
+	for i < len(a1) && a1[i] >= 'A' && a1[i] <= 'Z' {
+		col = col*len(Alphabet) + int(a1[i]-'A') + 1
+		i++
+	}
+	if i == 0 || i == len(a1) {
+		return CellPos{}, fmt.Errorf("invalid cell %q", a1)
+	}
+
+	row, err := strconv.Atoi(a1[i:])
+	if err != nil || row < 1 {
+		return CellPos{}, fmt.Errorf("invalid cell %q", a1)
+	}
+
+	return CellPos{Row: row - 1, Col: col - 1}, nil
+}
+
+// ParseCellRange parses a range in A1 notation, e.g. "A1:C5". A single cell
+// is parsed as a range of one cell.
+func ParseCellRange(a1 string) (CellRange, error) {
+	pieces := strings.Split(a1, ":")
+	if len(pieces) > 2 {
+		return CellRange{}, fmt.Errorf("invalid range %q", a1)
+	}
+
+	start, err := ParseCellPos(pieces[0])
+	if err != nil {
+		return CellRange{}, err
+	}
+	end := start
+	if len(pieces) == 2 {
+		end, err = ParseCellPos(pieces[1])
+		if err != nil {
+			return CellRange{}, err
+		}
+	}
+
+	return CellRange{Start: start, End: end}, nil
+}
+
+// ParseSheetRange parses a range in A1 notation qualified by its sheet name,
+// e.g. "Sheet1!A1:C5" or "'My sheet'!B2", as returned by the API.
+func ParseSheetRange(a1 string) (SheetRange, error) {
+	sep := strings.LastIndex(a1, "!")
+	if sep < 0 {
+		return SheetRange{}, fmt.Errorf("invalid range %q: missing sheet name", a1)
+	}
+
+	name := a1[:sep]
+	if len(name) >= 2 && name[0] == '\'' && name[len(name)-1] == '\'' {
+		name = strings.Replace(name[1:len(name)-1], "''", "'", -1)
+	}
+
+	cellRange, err := ParseCellRange(a1[sep+1:])
+	if err != nil {
+		return SheetRange{}, err
+	}
+
+	return SheetRange{SheetName: name, Range: cellRange}, nil
+}
+
+// GridRange converts the range to the API's representation, on the sheet
+// identified by sheetID.
+func (a CellRange) GridRange(sheetID int64) *sheets.GridRange {
+	return &sheets.GridRange{
+		SheetId:          sheetID,
+		StartRowIndex:    int64(a.Start.Row),
+		EndRowIndex:      int64(a.End.Row + 1),
+		StartColumnIndex: int64(a.Start.Col),
+		EndColumnIndex:   int64(a.End.Col + 1),
+	}
+}
+
+// cellRangeFromGrid converts a range from the API's representation. Unbounded
+// ranges extend to the end of grid.
+func cellRangeFromGrid(g *sheets.GridRange, grid *sheets.GridProperties) CellRange {
+	endRow, endCol := g.EndRowIndex, g.EndColumnIndex
+	if grid != nil {
+		if endRow == 0 {
+			endRow = grid.RowCount
+		}
+		if endCol == 0 {
+			endCol = grid.ColumnCount
+		}
+	}
+
+	return CellRange{
+		Start: CellPos{Row: int(g.StartRowIndex), Col: int(g.StartColumnIndex)},
+		End:   CellPos{Row: int(endRow) - 1, Col: int(endCol) - 1},
+	}
+}
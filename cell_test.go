@@ -67,3 +67,55 @@ func TestRange(t *testing.T) {
 
 	}
 }
+
+var parseTests = []struct {
+	a1       string
+	expected SheetRange
+}{
+	{"Sheet1!A1:A1", SheetRange{SheetName: "Sheet1", Range: CellRange{CellPos{0, 0}, CellPos{0, 0}}}},
+	{"Sheet1!B2", SheetRange{SheetName: "Sheet1", Range: CellRange{CellPos{1, 1}, CellPos{1, 1}}}},
+	{"Data!K1:L2", SheetRange{SheetName: "Data", Range: CellRange{CellPos{0, 10}, CellPos{1, 11}}}},
+	{"'My sheet'!D11:AAA13", SheetRange{SheetName: "My sheet", Range: CellRange{CellPos{10, 3}, CellPos{12, 702}}}},
+	{"'Bob''s'!ZZ1:ZZ2", SheetRange{SheetName: "Bob's", Range: CellRange{CellPos{0, 701}, CellPos{1, 701}}}},
+}
+
+func TestParseSheetRange(t *testing.T) {
+	for _, tt := range parseTests {
+		got, err := ParseSheetRange(tt.a1)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", tt.a1, err)
+			continue
+		}
+
+		if got != tt.expected {
+			t.Errorf("Wanted %+v, but got %+v for %s", tt.expected, got, tt.a1)
+		}
+	}
+
+	for _, tt := range posTests {
+		got, err := ParseCellPos(tt.expected)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", tt.expected, err)
+			continue
+		}
+
+		if got != tt.pos {
+			t.Errorf("Wanted %v, but got %v for %s", tt.pos, got, tt.expected)
+		}
+	}
+
+	for _, a1 := range []string{"A1:B2", "Sheet1!", "Sheet1!A", "Sheet1!1", "Sheet1!A0", "Sheet1!A1:B2:C3"} {
+		if _, err := ParseSheetRange(a1); err == nil {
+			t.Errorf("Expected error for %s, but got none", a1)
+		}
+	}
+}
+
+func TestGridRange(t *testing.T) {
+	got := CellRange{CellPos{10, 3}, CellPos{12, 4}}.GridRange(7)
+
+	if got.SheetId != 7 || got.StartRowIndex != 10 || got.EndRowIndex != 13 ||
+		got.StartColumnIndex != 3 || got.EndColumnIndex != 5 {
+		t.Errorf("Unexpected grid range %+v", got)
+	}
+}
@@ -0,0 +1,301 @@
+package sheets
+
+import (
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+type ChartType string
+
+const (
+	ChartLine    ChartType = "LINE"
+	ChartBar     ChartType = "BAR"
+	ChartColumn  ChartType = "COLUMN"
+	ChartScatter ChartType = "SCATTER"
+	ChartPie     ChartType = "PIE"
+)
+
+// Chart is a chart embedded in a sheet, plotting the values of Series
+// against Domain. Ranges are on the sheet the chart is added from, even when
+// the chart is put on a new sheet.
+//
+//	chart, err := sheet.AddChart(Chart{
+//		Type:        ChartLine,
+//		Title:       "Weekly signups",
+//		Domain:      weeks,
+//		Series:      []CellRange{signups},
+//		HeaderCount: 1,
+//		Anchor:      CellPos{0, 5},
+//	})
+type Chart struct {
+	// ID is set once the chart is added
+	ID int64
+
+	Type   ChartType
+	Title  string
+	Domain CellRange
+	// Series are the plotted values. Pie charts have exactly one.
+	Series []CellRange
+	// HeaderCount is the number of rows of Domain and Series holding labels
+	// rather than data, e.g. 1 to name each series after its header.
+	HeaderCount int
+
+	// XAxisTitle labels the domain axis and YAxisTitle the values axis,
+	// vertical and horizontal respectively for bar charts.
+	XAxisTitle string
+	YAxisTitle string
+
+	// Anchor is the cell of the top left corner of the chart, unless NewSheet
+	// puts the chart on a new sheet of its own.
+	Anchor   CellPos
+	NewSheet bool
+	// Width and Height are in pixels, or zero for the default size
+	Width  int
+	Height int
+}
+
+// AddChart adds c to the sheet, and returns it with its ID set.
+func (s *Sheet) AddChart(c Chart) (*Chart, error) {
+	req, err := addChartRequest(s.Properties.SheetId, c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.doBatch(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't add chart %s to %s", c.Title, s.Title())
+	}
+
+	if len(resp.Replies) == 0 || resp.Replies[0].AddChart == nil || resp.Replies[0].AddChart.Chart == nil {
+		return nil, errors.New("chart missing from response")
+	}
+	c.ID = resp.Replies[0].AddChart.Chart.ChartId
+
+	return &c, nil
+}
+
+// UpdateChart replaces the chart identified by c.ID with c. Charts can't be
+// moved to a new sheet once added.
+func (s *Sheet) UpdateChart(c Chart) error {
+	spec, err := c.spec(s.Properties.SheetId)
+	if err != nil {
+		return err
+	}
+
+	requests := []*sheets.Request{{
+		UpdateChartSpec: &sheets.UpdateChartSpecRequest{ChartId: c.ID, Spec: spec},
+	}}
+	if !c.NewSheet {
+		requests = append(requests, &sheets.Request{
+			UpdateEmbeddedObjectPosition: &sheets.UpdateEmbeddedObjectPositionRequest{
+				ObjectId:    c.ID,
+				NewPosition: c.position(s.Properties.SheetId),
+				Fields:      "overlayPosition",
+			},
+		})
+	}
+
+	_, err = s.doBatch(requests...)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update chart %d of %s", c.ID, s.Title())
+	}
+
+	return nil
+}
+
+func (s *Sheet) DeleteChart(id int64) error {
+	_, err := s.doBatch(&sheets.Request{
+		DeleteEmbeddedObject: &sheets.DeleteEmbeddedObjectRequest{ObjectId: id},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete chart %d of %s", id, s.Title())
+	}
+
+	return nil
+}
+
+// ListCharts returns the charts of the sheet that this package can represent.
+// Other kinds of charts are skipped.
+func (s *Sheet) ListCharts() []*Chart {
+	s.refresh()
+
+	var charts []*Chart
+	for _, chart := range s.Sheet.Charts {
+		if c := chartFromAPI(chart, s.Properties.GridProperties); c != nil {
+			charts = append(charts, c)
+		}
+	}
+
+	return charts
+}
+
+// AddChart adds c to the sheet titled title. Its ID is in the operation's
+// result.
+func (b *Batch) AddChart(title string, c Chart) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	req, err := addChartRequest(id, c)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(req)
+}
+
+func addChartRequest(sheetID int64, c Chart) (*sheets.Request, error) {
+	spec, err := c.spec(sheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sheets.Request{
+		AddChart: &sheets.AddChartRequest{
+			Chart: &sheets.EmbeddedChart{
+				Spec:     spec,
+				Position: c.position(sheetID),
+			},
+		},
+	}, nil
+}
+
+func (c Chart) spec(sheetID int64) (*sheets.ChartSpec, error) {
+	if len(c.Series) == 0 {
+		return nil, errors.New("chart has no series")
+	}
+
+	spec := &sheets.ChartSpec{Title: c.Title}
+
+	if c.Type == ChartPie {
+		if len(c.Series) != 1 {
+			return nil, errors.New("pie charts have exactly one series")
+		}
+
+		spec.PieChart = &sheets.PieChartSpec{
+			Domain:         chartData(c.Domain, sheetID),
+			Series:         chartData(c.Series[0], sheetID),
+			LegendPosition: "RIGHT_LEGEND",
+		}
+		return spec, nil
+	}
+
+	switch c.Type {
+	case ChartLine, ChartBar, ChartColumn, ChartScatter:
+	default:
+		return nil, errors.Errorf("unsupported chart type %s", c.Type)
+	}
+
+	domainAxis, valuesAxis := "BOTTOM_AXIS", "LEFT_AXIS"
+	if c.Type == ChartBar {
+		domainAxis, valuesAxis = valuesAxis, domainAxis
+	}
+
+	basic := &sheets.BasicChartSpec{
+		ChartType:      string(c.Type),
+		LegendPosition: "BOTTOM_LEGEND",
+		HeaderCount:    int64(c.HeaderCount),
+		Domains:        []*sheets.BasicChartDomain{{Domain: chartData(c.Domain, sheetID)}},
+		Axis: []*sheets.BasicChartAxis{
+			{Position: domainAxis, Title: c.XAxisTitle},
+			{Position: valuesAxis, Title: c.YAxisTitle},
+		},
+	}
+	for _, series := range c.Series {
+		basic.Series = append(basic.Series, &sheets.BasicChartSeries{
+			Series:     chartData(series, sheetID),
+			TargetAxis: valuesAxis,
+		})
+	}
+	spec.BasicChart = basic
+
+	return spec, nil
+}
+
+func (c Chart) position(sheetID int64) *sheets.EmbeddedObjectPosition {
+	if c.NewSheet {
+		return &sheets.EmbeddedObjectPosition{NewSheet: true}
+	}
+
+	return &sheets.EmbeddedObjectPosition{
+		OverlayPosition: &sheets.OverlayPosition{
+			AnchorCell: &sheets.GridCoordinate{
+				SheetId:         sheetID,
+				RowIndex:        int64(c.Anchor.Row),
+				ColumnIndex:     int64(c.Anchor.Col),
+				ForceSendFields: []string{"SheetId", "RowIndex", "ColumnIndex"},
+			},
+			WidthPixels:  int64(c.Width),
+			HeightPixels: int64(c.Height),
+		},
+	}
+}
+
+func chartData(r CellRange, sheetID int64) *sheets.ChartData {
+	return &sheets.ChartData{
+		SourceRange: &sheets.ChartSourceRange{
+			Sources: []*sheets.GridRange{r.GridRange(sheetID)},
+		},
+	}
+}
+
+func chartFromAPI(chart *sheets.EmbeddedChart, grid *sheets.GridProperties) *Chart {
+	if chart.Spec == nil {
+		return nil
+	}
+
+	c := &Chart{ID: chart.ChartId, Title: chart.Spec.Title}
+
+	switch {
+	case chart.Spec.PieChart != nil:
+		pie := chart.Spec.PieChart
+		c.Type = ChartPie
+		c.Domain = chartDataRange(pie.Domain, grid)
+		c.Series = []CellRange{chartDataRange(pie.Series, grid)}
+	case chart.Spec.BasicChart != nil:
+		basic := chart.Spec.BasicChart
+		c.Type = ChartType(basic.ChartType)
+		c.HeaderCount = int(basic.HeaderCount)
+		if len(basic.Domains) > 0 {
+			c.Domain = chartDataRange(basic.Domains[0].Domain, grid)
+		}
+		for _, series := range basic.Series {
+			c.Series = append(c.Series, chartDataRange(series.Series, grid))
+		}
+
+		domainAxis := "BOTTOM_AXIS"
+		if c.Type == ChartBar {
+			domainAxis = "LEFT_AXIS"
+		}
+		for _, axis := range basic.Axis {
+			if axis.Position == domainAxis {
+				c.XAxisTitle = axis.Title
+			} else {
+				c.YAxisTitle = axis.Title
+			}
+		}
+	default:
+		return nil
+	}
+
+	if pos := chart.Position; pos != nil {
+		c.NewSheet = pos.NewSheet
+		if overlay := pos.OverlayPosition; overlay != nil {
+			c.Width, c.Height = int(overlay.WidthPixels), int(overlay.HeightPixels)
+			if overlay.AnchorCell != nil {
+				c.Anchor = CellPos{int(overlay.AnchorCell.RowIndex), int(overlay.AnchorCell.ColumnIndex)}
+			}
+		}
+	}
+
+	return c
+}
+
+func chartDataRange(data *sheets.ChartData, grid *sheets.GridProperties) CellRange {
+	if data == nil || data.SourceRange == nil || len(data.SourceRange.Sources) == 0 {
+		return CellRange{}
+	}
+
+	return cellRangeFromGrid(data.SourceRange.Sources[0], grid)
+}
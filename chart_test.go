@@ -0,0 +1,91 @@
+package sheets
+
+import (
+	"encoding/json"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestChartSpec(t *testing.T) {
+	weeks := CellRange{CellPos{0, 0}, CellPos{10, 0}}
+	signups := CellRange{CellPos{0, 1}, CellPos{10, 1}}
+
+	bar, err := Chart{Type: ChartBar, Domain: weeks, Series: []CellRange{signups}, XAxisTitle: "Week"}.spec(7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bar.BasicChart.Axis[0].Position != "LEFT_AXIS" || bar.BasicChart.Axis[0].Title != "Week" {
+		t.Errorf("Wanted the domain on the left axis of bar charts, but got %+v", bar.BasicChart.Axis[0])
+	}
+	if bar.BasicChart.Series[0].TargetAxis != "BOTTOM_AXIS" {
+		t.Errorf("Wanted series on the bottom axis, but got %s", bar.BasicChart.Series[0].TargetAxis)
+	}
+
+	pie, err := Chart{Type: ChartPie, Title: "Share", Domain: weeks, Series: []CellRange{signups}}.spec(7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	encoded, _ := json.Marshal(pie)
+	expected := `{"pieChart":{"domain":{"sourceRange":{"sources":[{"endColumnIndex":1,"endRowIndex":11,"sheetId":7}]}},"legendPosition":"RIGHT_LEGEND",` +
+		`"series":{"sourceRange":{"sources":[{"endColumnIndex":2,"endRowIndex":11,"sheetId":7,"startColumnIndex":1}]}}},"title":"Share"}`
+	if string(encoded) != expected {
+		t.Errorf("Wanted %s, but got %s", expected, encoded)
+	}
+
+	for _, invalid := range []Chart{
+		{Type: ChartLine, Domain: weeks},
+		{Type: ChartPie, Domain: weeks, Series: []CellRange{signups, signups}},
+		{Type: "RADAR", Domain: weeks, Series: []CellRange{signups}},
+	} {
+		if _, err := invalid.spec(7); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestChartRoundTrip(t *testing.T) {
+	c := Chart{
+		Type:        ChartColumn,
+		Title:       "Signups",
+		Domain:      CellRange{CellPos{0, 0}, CellPos{10, 0}},
+		Series:      []CellRange{{CellPos{0, 1}, CellPos{10, 1}}, {CellPos{0, 2}, CellPos{10, 2}}},
+		HeaderCount: 1,
+		XAxisTitle:  "Week",
+		YAxisTitle:  "Users",
+		Anchor:      CellPos{2, 5},
+		Width:       600,
+	}
+
+	req, err := addChartRequest(7, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ss := cacheTestSpreadsheet()
+	chart := req.AddChart.Chart
+	chart.ChartId = 99
+	chart.Position.OverlayPosition.AnchorCell.SheetId = 1
+	ss.applyLocally([]*sheets.Request{req}, []*sheets.Response{{AddChart: &sheets.AddChartResponse{Chart: chart}}})
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+
+	charts := ss.SheetByID(1).ListCharts()
+	if len(charts) != 1 {
+		t.Fatalf("Wanted 1 chart, but got %d", len(charts))
+	}
+
+	got := charts[0]
+	c.ID = 99
+	if got.ID != c.ID || got.Type != c.Type || got.Title != c.Title || got.Domain != c.Domain ||
+		len(got.Series) != 2 || got.Series[1] != c.Series[1] || got.HeaderCount != 1 ||
+		got.XAxisTitle != c.XAxisTitle || got.YAxisTitle != c.YAxisTitle || got.Anchor != c.Anchor || got.Width != 600 {
+		t.Errorf("Wanted %+v, but got %+v", c, got)
+	}
+
+	ss.applyLocally([]*sheets.Request{{DeleteEmbeddedObject: &sheets.DeleteEmbeddedObjectRequest{ObjectId: 99}}}, nil)
+	if len(ss.SheetByID(1).ListCharts()) != 0 {
+		t.Error("Expected the chart to be deleted")
+	}
+}
@@ -0,0 +1,102 @@
+package sheets
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Clear clears all the values in the sheet, keeping formatting.
+func (s *Sheet) Clear() error {
+	return s.clear(quoteSheetName(s.Title()))
+}
+
+// ClearRange clears the values in r, keeping formatting.
+func (s *Sheet) ClearRange(r CellRange) error {
+	return s.clear(fmt.Sprintf("%s!%s", quoteSheetName(s.Title()), r.String()))
+}
+
+func (s *Sheet) clear(sheetRange string) error {
+	req := s.Client.Sheets.Spreadsheets.Values.Clear(s.Spreadsheet.Id(), sheetRange, &sheets.ClearValuesRequest{})
+
+	return googleRetry(func() error {
+		_, err := req.Do(s.Client.options...)
+		return err
+	})
+}
+
+// BatchClear clears the values in all the ranges with a single request.
+func (s *Spreadsheet) BatchClear(ranges ...SheetRange) error {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	clearReq := sheets.BatchClearValuesRequest{}
+	for _, r := range ranges {
+		clearReq.Ranges = append(clearReq.Ranges, r.quotedA1())
+	}
+
+	return googleRetry(func() error {
+		_, err := s.Client.Sheets.Spreadsheets.Values.BatchClear(s.Id(), &clearReq).Do(s.Client.options...)
+		return err
+	})
+}
+
+// ReplaceContents writes data at the top left of the sheet as Update does, so
+// that values are interpreted according to the write options, then clears the
+// values left outside of it. Readers never see the sheet empty, but can see
+// the new data next to leftovers of the old one in between the two calls.
+func (s *Sheet) ReplaceContents(data [][]interface{}, opts ...WriteOption) error {
+	if len(data) == 0 {
+		return s.Clear()
+	}
+
+	// Short rows are padded so that they overwrite the whole width
+	written := dataRange(s.TopLeft(), data)
+	width := written.End.Col - written.Start.Col + 1
+	padded := make([][]interface{}, len(data))
+	for i, row := range data {
+		padded[i] = make([]interface{}, width)
+		copy(padded[i], row)
+		for j := len(row); j < width; j++ {
+			padded[i][j] = ""
+		}
+	}
+
+	if _, err := s.UpdateFromPositionChunked(padded, s.TopLeft(), opts...); err != nil {
+		return errors.Wrapf(err, "couldn't replace contents of %s", s.Title())
+	}
+
+	if err := s.Spreadsheet.BatchClear(s.leftoverRanges(written)...); err != nil {
+		return errors.Wrapf(err, "couldn't clear %s outside of %s", s.Title(), written.String())
+	}
+
+	return nil
+}
+
+// leftoverRanges returns the parts of the grid below and to the right of
+// written.
+func (s *Sheet) leftoverRanges(written CellRange) []SheetRange {
+	if s.Properties == nil || s.Properties.GridProperties == nil {
+		return nil
+	}
+	lastRow := int(s.Properties.GridProperties.RowCount) - 1
+	lastCol := int(s.Properties.GridProperties.ColumnCount) - 1
+
+	var ranges []SheetRange
+	if written.End.Col < lastCol {
+		ranges = append(ranges, SheetRange{SheetName: s.Title(), Range: CellRange{
+			Start: CellPos{written.Start.Row, written.End.Col + 1},
+			End:   CellPos{written.End.Row, lastCol},
+		}})
+	}
+	if written.End.Row < lastRow {
+		ranges = append(ranges, SheetRange{SheetName: s.Title(), Range: CellRange{
+			Start: CellPos{written.End.Row + 1, 0},
+			End:   CellPos{lastRow, lastCol},
+		}})
+	}
+
+	return ranges
+}
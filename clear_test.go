@@ -0,0 +1,66 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestReplaceContents(t *testing.T) {
+	var (
+		calls   []string
+		written [][]interface{}
+		cleared []string
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path+" "+r.URL.Query().Get("valueInputOption"))
+
+		if strings.HasSuffix(r.URL.Path, ":batchClear") {
+			var req sheets.BatchClearValuesRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			cleared = req.Ranges
+		} else {
+			var req sheets.ValueRange
+			json.NewDecoder(r.Body).Decode(&req)
+			written = req.Values
+		}
+
+		json.NewEncoder(w).Encode(&sheets.UpdateValuesResponse{})
+	}))
+	client.writeOptions = []WriteOption{ValueInput(ValueInputUserEntered)}
+
+	ss := &Spreadsheet{Client: client, Spreadsheet: &sheets.Spreadsheet{SpreadsheetId: "ssid"}}
+	sheet := &Sheet{&sheets.Sheet{Properties: &sheets.SheetProperties{
+		Title:          "Data",
+		GridProperties: &sheets.GridProperties{RowCount: 10, ColumnCount: 5},
+	}}, ss, client}
+
+	err := sheet.ReplaceContents([][]interface{}{{"2020-01-31", "15%"}, {"x"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Values are parsed by the API rather than sent as cell data, and only
+	// what's outside of them is cleared afterwards
+	expected := []string{
+		"/v4/spreadsheets/ssid/values/'Data'!A1:B2 USER_ENTERED",
+		"/v4/spreadsheets/ssid/values:batchClear ",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Wanted %v, but got %v", expected, calls)
+	}
+
+	wantWritten := [][]interface{}{{"2020-01-31", "15%"}, {"x", ""}}
+	if !reflect.DeepEqual(written, wantWritten) {
+		t.Errorf("Wanted values %v, but got %v", wantWritten, written)
+	}
+
+	wantCleared := []string{"'Data'!C1:E2", "'Data'!A3:E10"}
+	if !reflect.DeepEqual(cleared, wantCleared) {
+		t.Errorf("Wanted cleared ranges %v, but got %v", wantCleared, cleared)
+	}
+}
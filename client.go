@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
 	retry "github.com/avast/retry-go"
@@ -24,7 +25,20 @@ type Client struct {
 	Sheets *sheets.Service
 	Drive  *drive.Service
 
-	options []googleapi.CallOption
+	// LightweightBatches stops DoBatch from asking for the whole updated
+	// spreadsheet in batchUpdate responses, which is slow for spreadsheets
+	// with many sheets. The known effects of the requests are applied to the
+	// cached Spreadsheet instead, which is marked stale otherwise, see
+	// Spreadsheet.Stale.
+	LightweightBatches bool
+
+	options      []googleapi.CallOption
+	writeOptions []WriteOption
+
+	// Cached spreadsheets whose metadata is out of date. Copies of the same
+	// spreadsheet are cached separately, so they're tracked separately.
+	staleMu sync.Mutex
+	stale   map[*sheets.Spreadsheet]bool
 }
 
 func NewServiceAccountClientFromReader(creds io.Reader) (*Client, error) {
@@ -99,6 +113,16 @@ func (c *Client) ListFiles(query string) ([]*drive.File, error) {
 }
 
 func (c *Client) CopySpreadsheetFrom(fileID, newName string) (*Spreadsheet, error) {
+	id, err := c.copyFile(fileID, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSpreadsheet(id)
+}
+
+// copyFile copies the Drive file fileID and returns the ID of the copy.
+func (c *Client) copyFile(fileID, newName string) (string, error) {
 	var file *drive.File
 	err := googleRetry(func() error {
 		var rerr error
@@ -109,10 +133,10 @@ func (c *Client) CopySpreadsheetFrom(fileID, newName string) (*Spreadsheet, erro
 		return rerr
 	})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return c.GetSpreadsheet(file.Id)
+	return file.Id, nil
 }
 
 func (c *Client) CreateSpreadsheetFromTsv(title string, reader io.Reader) (*Spreadsheet, error) {
@@ -176,7 +200,7 @@ func (c *Client) GetSpreadsheet(spreadsheetId string) (*Spreadsheet, error) {
 		return nil, err
 	}
 
-	return &Spreadsheet{c, ssInfo}, nil
+	return &Spreadsheet{Client: c, Spreadsheet: ssInfo}, nil
 }
 
 func (c *Client) GetSpreadsheetWithData(spreadsheetId string) (*Spreadsheet, error) {
@@ -191,7 +215,7 @@ func (c *Client) GetSpreadsheetWithData(spreadsheetId string) (*Spreadsheet, err
 		return nil, err
 	}
 
-	return &Spreadsheet{c, ssInfo}, nil
+	return &Spreadsheet{Client: c, Spreadsheet: ssInfo}, nil
 }
 
 func (c *Client) Delete(fileId string) error {
@@ -1,8 +1,12 @@
 package sheets
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
 )
 
 var configTests = []struct {
@@ -37,3 +41,17 @@ func TestNewServiceAccountClient(t *testing.T) {
 		}
 	}
 }
+
+// newTestClient returns a client whose Sheets service talks to handler.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	sheetsSrv, err := sheets.New(srv.Client())
+	if err != nil {
+		t.Fatalf("couldn't initialize sheets client: %v", err)
+	}
+	sheetsSrv.BasePath = srv.URL + "/"
+
+	return &Client{Sheets: sheetsSrv}
+}
@@ -0,0 +1,107 @@
+package sheets
+
+import (
+	"strconv"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Condition is a condition on cell values, used by conditional formatting,
+// data validation and filters. Type is one of the API's ConditionType values
+// and Values its arguments, as they would be entered in the UI.
+type Condition struct {
+	Type   string
+	Values []string
+}
+
+func NumberGreater(v float64) Condition {
+	return Condition{"NUMBER_GREATER", []string{formatNumber(v)}}
+}
+
+func NumberGreaterOrEqual(v float64) Condition {
+	return Condition{"NUMBER_GREATER_THAN_EQ", []string{formatNumber(v)}}
+}
+
+func NumberLess(v float64) Condition {
+	return Condition{"NUMBER_LESS", []string{formatNumber(v)}}
+}
+
+func NumberLessOrEqual(v float64) Condition {
+	return Condition{"NUMBER_LESS_THAN_EQ", []string{formatNumber(v)}}
+}
+
+func NumberEqual(v float64) Condition {
+	return Condition{"NUMBER_EQ", []string{formatNumber(v)}}
+}
+
+func NumberNotEqual(v float64) Condition {
+	return Condition{"NUMBER_NOT_EQ", []string{formatNumber(v)}}
+}
+
+func NumberBetween(min, max float64) Condition {
+	return Condition{"NUMBER_BETWEEN", []string{formatNumber(min), formatNumber(max)}}
+}
+
+func NumberNotBetween(min, max float64) Condition {
+	return Condition{"NUMBER_NOT_BETWEEN", []string{formatNumber(min), formatNumber(max)}}
+}
+
+func TextContains(s string) Condition {
+	return Condition{"TEXT_CONTAINS", []string{s}}
+}
+
+func TextNotContains(s string) Condition {
+	return Condition{"TEXT_NOT_CONTAINS", []string{s}}
+}
+
+func TextStartsWith(s string) Condition {
+	return Condition{"TEXT_STARTS_WITH", []string{s}}
+}
+
+func TextEndsWith(s string) Condition {
+	return Condition{"TEXT_ENDS_WITH", []string{s}}
+}
+
+func TextEqual(s string) Condition {
+	return Condition{"TEXT_EQ", []string{s}}
+}
+
+func Blank() Condition {
+	return Condition{Type: "BLANK"}
+}
+
+func NotBlank() Condition {
+	return Condition{Type: "NOT_BLANK"}
+}
+
+// CustomFormula is true when formula, e.g. "=$B2>$C2", evaluates to true.
+// Relative references are relative to the top left cell of the range.
+func CustomFormula(formula string) Condition {
+	return Condition{"CUSTOM_FORMULA", []string{formula}}
+}
+
+func (c Condition) booleanCondition() *sheets.BooleanCondition {
+	cond := &sheets.BooleanCondition{Type: c.Type}
+	for _, v := range c.Values {
+		cond.Values = append(cond.Values, &sheets.ConditionValue{UserEnteredValue: v})
+	}
+
+	return cond
+}
+
+func conditionFromAPI(cond *sheets.BooleanCondition) Condition {
+	c := Condition{Type: cond.Type}
+	for _, v := range cond.Values {
+		if v.RelativeDate != "" {
+			c.Values = append(c.Values, v.RelativeDate)
+		} else {
+			c.Values = append(c.Values, v.UserEnteredValue)
+		}
+	}
+
+	return c
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,260 @@
+package sheets
+
+import (
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// ConditionalRule formats the cells of Ranges either with Style when they
+// match Condition, or with a color scale when Gradient is set.
+//
+// Conditional formats only support bold, italic, underline, strikethrough,
+// text color and background styles.
+type ConditionalRule struct {
+	Ranges []CellRange
+
+	Condition *Condition
+	Style     *Style
+
+	Gradient *ColorScale
+}
+
+// ColorScale colors cells according to their value, interpolating between
+// Min, Mid (optional) and Max.
+type ColorScale struct {
+	Min *ScalePoint
+	Mid *ScalePoint
+	Max *ScalePoint
+}
+
+// ScalePoint is a point of a ColorScale. Type is one of MIN, MAX, NUMBER,
+// PERCENT and PERCENTILE, and Value its argument for the last three.
+type ScalePoint struct {
+	Color *sheets.Color
+	Type  string
+	Value string
+}
+
+// ScaleMin is the minimum value of the range.
+func ScaleMin(color *sheets.Color) *ScalePoint {
+	return &ScalePoint{Color: color, Type: "MIN"}
+}
+
+// ScaleMax is the maximum value of the range.
+func ScaleMax(color *sheets.Color) *ScalePoint {
+	return &ScalePoint{Color: color, Type: "MAX"}
+}
+
+func ScaleNumber(v float64, color *sheets.Color) *ScalePoint {
+	return &ScalePoint{Color: color, Type: "NUMBER", Value: formatNumber(v)}
+}
+
+func ScalePercentile(p float64, color *sheets.Color) *ScalePoint {
+	return &ScalePoint{Color: color, Type: "PERCENTILE", Value: formatNumber(p)}
+}
+
+// ConditionalRules returns the sheet's conditional format rules, in
+// priority order. A rule's position is the index used to update or delete it.
+func (s *Sheet) ConditionalRules() []*ConditionalRule {
+	s.refresh()
+
+	rules := make([]*ConditionalRule, len(s.Sheet.ConditionalFormats))
+	for i, rule := range s.Sheet.ConditionalFormats {
+		rules[i] = conditionalRuleFromAPI(rule, s.Properties.GridProperties)
+	}
+
+	return rules
+}
+
+// AddConditionalFormat adds rule after the sheet's existing rules.
+func (s *Sheet) AddConditionalFormat(rule *ConditionalRule) error {
+	// The rule's index depends on the existing rules
+	if err := s.Spreadsheet.refreshIfStale(); err != nil {
+		return err
+	}
+	s.refresh()
+
+	apiRule, err := rule.apiRule(s.Properties.SheetId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.doBatch(&sheets.Request{
+		AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Rule:  apiRule,
+			Index: int64(len(s.Sheet.ConditionalFormats)),
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't add conditional format to %s", s.Title())
+	}
+
+	return nil
+}
+
+// UpdateConditionalFormat replaces the rule at index.
+func (s *Sheet) UpdateConditionalFormat(index int, rule *ConditionalRule) error {
+	apiRule, err := rule.apiRule(s.Properties.SheetId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.doBatch(&sheets.Request{
+		UpdateConditionalFormatRule: &sheets.UpdateConditionalFormatRuleRequest{
+			SheetId: s.Properties.SheetId,
+			Index:   int64(index),
+			Rule:    apiRule,
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update conditional format %d of %s", index, s.Title())
+	}
+
+	return nil
+}
+
+// DeleteConditionalFormat deletes the rule at index. The rules after it move
+// up by one.
+func (s *Sheet) DeleteConditionalFormat(index int) error {
+	_, err := s.doBatch(&sheets.Request{
+		DeleteConditionalFormatRule: &sheets.DeleteConditionalFormatRuleRequest{
+			SheetId: s.Properties.SheetId,
+			Index:   int64(index),
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete conditional format %d of %s", index, s.Title())
+	}
+
+	return nil
+}
+
+// AddConditionalFormat adds rule to the sheet titled title, at index in the
+// sheet's rules.
+func (b *Batch) AddConditionalFormat(title string, rule *ConditionalRule, index int) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	apiRule, err := rule.apiRule(id)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(&sheets.Request{
+		AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Rule:  apiRule,
+			Index: int64(index),
+		},
+	})
+}
+
+func (r *ConditionalRule) apiRule(sheetID int64) (*sheets.ConditionalFormatRule, error) {
+	if len(r.Ranges) == 0 {
+		return nil, errors.New("conditional format has no ranges")
+	}
+
+	rule := &sheets.ConditionalFormatRule{}
+	for _, cellRange := range r.Ranges {
+		rule.Ranges = append(rule.Ranges, cellRange.GridRange(sheetID))
+	}
+
+	switch {
+	case r.Condition != nil && r.Gradient == nil:
+		var format *sheets.CellFormat
+		if r.Style != nil {
+			format, _ = r.Style.CellFormat()
+		}
+		rule.BooleanRule = &sheets.BooleanRule{
+			Condition: r.Condition.booleanCondition(),
+			Format:    format,
+		}
+	case r.Gradient != nil && r.Condition == nil:
+		if r.Gradient.Min == nil || r.Gradient.Max == nil {
+			return nil, errors.New("color scale needs a min and a max point")
+		}
+		rule.GradientRule = &sheets.GradientRule{
+			Minpoint: r.Gradient.Min.interpolationPoint(),
+			Midpoint: r.Gradient.Mid.interpolationPoint(),
+			Maxpoint: r.Gradient.Max.interpolationPoint(),
+		}
+	default:
+		return nil, errors.New("conditional format needs either a condition or a color scale")
+	}
+
+	return rule, nil
+}
+
+func (p *ScalePoint) interpolationPoint() *sheets.InterpolationPoint {
+	if p == nil {
+		return nil
+	}
+
+	return &sheets.InterpolationPoint{Color: p.Color, Type: p.Type, Value: p.Value}
+}
+
+func conditionalRuleFromAPI(rule *sheets.ConditionalFormatRule, grid *sheets.GridProperties) *ConditionalRule {
+	r := &ConditionalRule{}
+	for _, gridRange := range rule.Ranges {
+		r.Ranges = append(r.Ranges, cellRangeFromGrid(gridRange, grid))
+	}
+
+	if rule.BooleanRule != nil {
+		if rule.BooleanRule.Condition != nil {
+			cond := conditionFromAPI(rule.BooleanRule.Condition)
+			r.Condition = &cond
+		}
+		r.Style = styleFromFormat(rule.BooleanRule.Format)
+	}
+
+	if rule.GradientRule != nil {
+		r.Gradient = &ColorScale{
+			Min: scalePointFromAPI(rule.GradientRule.Minpoint),
+			Mid: scalePointFromAPI(rule.GradientRule.Midpoint),
+			Max: scalePointFromAPI(rule.GradientRule.Maxpoint),
+		}
+	}
+
+	return r
+}
+
+func scalePointFromAPI(p *sheets.InterpolationPoint) *ScalePoint {
+	if p == nil {
+		return nil
+	}
+
+	return &ScalePoint{Color: p.Color, Type: p.Type, Value: p.Value}
+}
+
+// styleFromFormat returns a style setting the properties that a conditional
+// format can set.
+func styleFromFormat(format *sheets.CellFormat) *Style {
+	style := NewStyle()
+	if format == nil {
+		return style
+	}
+
+	if format.BackgroundColor != nil {
+		style.Background(format.BackgroundColor)
+	}
+	if tf := format.TextFormat; tf != nil {
+		if tf.Bold {
+			style.Bold(true)
+		}
+		if tf.Italic {
+			style.Italic(true)
+		}
+		if tf.Underline {
+			style.Underline(true)
+		}
+		if tf.Strikethrough {
+			style.Strikethrough(true)
+		}
+		if tf.ForegroundColor != nil {
+			style.TextColor(tf.ForegroundColor)
+		}
+	}
+
+	return style
+}
@@ -0,0 +1,79 @@
+package sheets
+
+import (
+	"reflect"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestConditionalRuleRoundTrip(t *testing.T) {
+	red := RGB(255, 0, 0)
+	grid := &sheets.GridProperties{RowCount: 100, ColumnCount: 10}
+
+	rules := []*ConditionalRule{
+		{
+			Ranges:    []CellRange{{CellPos{1, 2}, CellPos{10, 2}}},
+			Condition: &Condition{"NUMBER_LESS", []string{"0"}},
+			Style:     NewStyle().Background(red).Bold(true),
+		},
+		{
+			Ranges:    []CellRange{{CellPos{1, 0}, CellPos{99, 9}}},
+			Condition: &Condition{"CUSTOM_FORMULA", []string{"=$B2>$C2"}},
+			Style:     NewStyle().TextColor(red),
+		},
+		{
+			Ranges:   []CellRange{{CellPos{1, 3}, CellPos{10, 3}}},
+			Gradient: &ColorScale{Min: ScaleMin(red), Mid: ScalePercentile(50, RGB(255, 255, 0)), Max: ScaleMax(RGB(0, 255, 0))},
+		},
+	}
+
+	for _, rule := range rules {
+		apiRule, err := rule.apiRule(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if apiRule.Ranges[0].SheetId != 4 {
+			t.Errorf("Wanted sheet 4, but got %d", apiRule.Ranges[0].SheetId)
+		}
+
+		got := conditionalRuleFromAPI(apiRule, grid)
+		if !reflect.DeepEqual(got.Ranges, rule.Ranges) || !reflect.DeepEqual(got.Condition, rule.Condition) ||
+			!reflect.DeepEqual(got.Gradient, rule.Gradient) {
+			t.Errorf("Wanted %+v, but got %+v", rule, got)
+		}
+		if rule.Style != nil {
+			wantFormat, wantFields := rule.Style.CellFormat()
+			gotFormat, gotFields := got.Style.CellFormat()
+			if wantFields != gotFields || !reflect.DeepEqual(wantFormat, gotFormat) {
+				t.Errorf("Wanted style %s %+v, but got %s %+v", wantFields, wantFormat, gotFields, gotFormat)
+			}
+		}
+	}
+}
+
+func TestConditionalRuleValidation(t *testing.T) {
+	r := []CellRange{{CellPos{0, 0}, CellPos{1, 1}}}
+	cond := NotBlank()
+
+	for _, rule := range []*ConditionalRule{
+		{Condition: &cond},
+		{Ranges: r},
+		{Ranges: r, Condition: &cond, Gradient: &ColorScale{}},
+		{Ranges: r, Gradient: &ColorScale{Min: ScaleMin(nil)}},
+	} {
+		if _, err := rule.apiRule(0); err == nil {
+			t.Errorf("Expected error for %+v, but got none", rule)
+		}
+	}
+}
+
+func TestCellRangeFromGrid(t *testing.T) {
+	grid := &sheets.GridProperties{RowCount: 100, ColumnCount: 10}
+
+	got := cellRangeFromGrid(&sheets.GridRange{StartRowIndex: 1}, grid)
+	expected := CellRange{CellPos{1, 0}, CellPos{99, 9}}
+	if got != expected {
+		t.Errorf("Wanted %v, but got %v", expected, got)
+	}
+}
@@ -0,0 +1,161 @@
+package sheets
+
+import (
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Dimension is either the rows or the columns of a sheet.
+type Dimension string
+
+const (
+	DimensionRows    Dimension = "ROWS"
+	DimensionColumns Dimension = "COLUMNS"
+)
+
+// Span returns the first index and the number of rows or columns covered by
+// the range along dim.
+func (a CellRange) Span(dim Dimension) (int, int) {
+	if dim == DimensionColumns {
+		return a.Start.Col, a.End.Col - a.Start.Col + 1
+	}
+
+	return a.Start.Row, a.End.Row - a.Start.Row + 1
+}
+
+// InsertRows inserts count empty rows before row start. When
+// inheritFromBefore is set the new rows take their properties from the row
+// above them, otherwise from the row below.
+func (s *Sheet) InsertRows(start, count int, inheritFromBefore bool) error {
+	return s.InsertDimension(DimensionRows, start, count, inheritFromBefore)
+}
+
+// InsertColumns inserts count empty columns before column start. When
+// inheritFromBefore is set the new columns take their properties from the
+// column to their left, otherwise from the column to their right.
+func (s *Sheet) InsertColumns(start, count int, inheritFromBefore bool) error {
+	return s.InsertDimension(DimensionColumns, start, count, inheritFromBefore)
+}
+
+// DeleteRows deletes count rows starting at row start. Rows below them move
+// up.
+func (s *Sheet) DeleteRows(start, count int) error {
+	return s.DeleteDimension(DimensionRows, start, count)
+}
+
+// DeleteColumns deletes count columns starting at column start. Columns to
+// their right move left.
+func (s *Sheet) DeleteColumns(start, count int) error {
+	return s.DeleteDimension(DimensionColumns, start, count)
+}
+
+// MoveRows moves count rows starting at row start so that they begin at row
+// destination. The destination is an index in the sheet before the rows are
+// removed from their original position.
+func (s *Sheet) MoveRows(start, count, destination int) error {
+	return s.MoveDimension(DimensionRows, start, count, destination)
+}
+
+// MoveColumns moves count columns starting at column start so that they
+// begin at column destination. The destination is an index in the sheet
+// before the columns are removed from their original position.
+func (s *Sheet) MoveColumns(start, count, destination int) error {
+	return s.MoveDimension(DimensionColumns, start, count, destination)
+}
+
+// InsertDimension is InsertRows or InsertColumns depending on dim.
+func (s *Sheet) InsertDimension(dim Dimension, start, count int, inheritFromBefore bool) error {
+	if count <= 0 {
+		return errors.New("count must be positive")
+	}
+
+	_, err := s.doBatch(insertDimensionRequest(s.Properties.SheetId, dim, start, count, inheritFromBefore))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't insert %s in %s", dim, s.Title())
+	}
+
+	return nil
+}
+
+// DeleteDimension is DeleteRows or DeleteColumns depending on dim.
+func (s *Sheet) DeleteDimension(dim Dimension, start, count int) error {
+	if count <= 0 {
+		return errors.New("count must be positive")
+	}
+
+	_, err := s.doBatch(deleteDimensionRequest(s.Properties.SheetId, dim, start, count))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete %s in %s", dim, s.Title())
+	}
+
+	return nil
+}
+
+// MoveDimension is MoveRows or MoveColumns depending on dim.
+func (s *Sheet) MoveDimension(dim Dimension, start, count, destination int) error {
+	if count <= 0 {
+		return errors.New("count must be positive")
+	}
+
+	_, err := s.doBatch(moveDimensionRequest(s.Properties.SheetId, dim, start, count, destination))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't move %s in %s", dim, s.Title())
+	}
+
+	return nil
+}
+
+// InsertDimensionRange inserts as many rows or columns as r spans, at the
+// position of r.
+func (s *Sheet) InsertDimensionRange(dim Dimension, r CellRange, inheritFromBefore bool) error {
+	start, count := r.Span(dim)
+	return s.InsertDimension(dim, start, count, inheritFromBefore)
+}
+
+// DeleteDimensionRange deletes the rows or columns spanned by r.
+func (s *Sheet) DeleteDimensionRange(dim Dimension, r CellRange) error {
+	start, count := r.Span(dim)
+	return s.DeleteDimension(dim, start, count)
+}
+
+// MoveDimensionRange moves the rows or columns spanned by r to destination.
+func (s *Sheet) MoveDimensionRange(dim Dimension, r CellRange, destination int) error {
+	start, count := r.Span(dim)
+	return s.MoveDimension(dim, start, count, destination)
+}
+
+func dimensionRange(sheetID int64, dim Dimension, start, count int) *sheets.DimensionRange {
+	return &sheets.DimensionRange{
+		SheetId:    sheetID,
+		Dimension:  string(dim),
+		StartIndex: int64(start),
+		EndIndex:   int64(start + count),
+	}
+}
+
+func insertDimensionRequest(sheetID int64, dim Dimension, start, count int, inheritFromBefore bool) *sheets.Request {
+	return &sheets.Request{
+		InsertDimension: &sheets.InsertDimensionRequest{
+			Range:             dimensionRange(sheetID, dim, start, count),
+			InheritFromBefore: inheritFromBefore,
+		},
+	}
+}
+
+func deleteDimensionRequest(sheetID int64, dim Dimension, start, count int) *sheets.Request {
+	return &sheets.Request{
+		DeleteDimension: &sheets.DeleteDimensionRequest{
+			Range: dimensionRange(sheetID, dim, start, count),
+		},
+	}
+}
+
+func moveDimensionRequest(sheetID int64, dim Dimension, start, count, destination int) *sheets.Request {
+	return &sheets.Request{
+		MoveDimension: &sheets.MoveDimensionRequest{
+			Source:           dimensionRange(sheetID, dim, start, count),
+			DestinationIndex: int64(destination),
+			ForceSendFields:  []string{"DestinationIndex"},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestSpan(t *testing.T) {
+	r := CellRange{CellPos{2, 1}, CellPos{4, 5}}
+
+	if start, count := r.Span(DimensionRows); start != 2 || count != 3 {
+		t.Errorf("Wanted rows 2+3, but got %d+%d", start, count)
+	}
+	if start, count := r.Span(DimensionColumns); start != 1 || count != 5 {
+		t.Errorf("Wanted columns 1+5, but got %d+%d", start, count)
+	}
+}
+
+func TestInsertRowsRefreshesGrid(t *testing.T) {
+	var got *sheets.InsertDimensionRequest
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = req.Requests[0].InsertDimension
+
+		json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{
+			UpdatedSpreadsheet: &sheets.Spreadsheet{
+				SpreadsheetId: "ssid",
+				Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{
+					SheetId:        3,
+					Title:          "Data",
+					GridProperties: &sheets.GridProperties{RowCount: 1002, ColumnCount: 26},
+				}}},
+			},
+		})
+	}))
+
+	ss := &Spreadsheet{client, &sheets.Spreadsheet{
+		SpreadsheetId: "ssid",
+		Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{
+			SheetId:        3,
+			Title:          "Data",
+			GridProperties: &sheets.GridProperties{RowCount: 1000, ColumnCount: 26},
+		}}},
+	}}
+	sheet := ss.GetSheet("Data")
+
+	if err := sheet.InsertDimensionRange(DimensionRows, CellRange{CellPos{4, 0}, CellPos{5, 3}}, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got == nil || got.Range.SheetId != 3 || got.Range.Dimension != "ROWS" ||
+		got.Range.StartIndex != 4 || got.Range.EndIndex != 6 || !got.InheritFromBefore {
+		t.Errorf("Unexpected request %+v", got)
+	}
+
+	if rows := sheet.Properties.GridProperties.RowCount; rows != 1002 {
+		t.Errorf("Wanted 1002 rows, but got %d", rows)
+	}
+}
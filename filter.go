@@ -0,0 +1,293 @@
+package sheets
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// SortSpec sorts rows by the values of a column. Column is the index of the
+// column in the sheet, 0 being column A.
+type SortSpec struct {
+	Column     int
+	Descending bool
+
+	// Header picks the column by its name in the header row instead of
+	// Column. Only supported by Sheet.SortWithHeader: filters reject it.
+	Header string
+}
+
+// ColumnCriteria hides the rows of a filter that don't match Condition, or
+// whose value is one of HiddenValues.
+type ColumnCriteria struct {
+	Condition    *Condition
+	HiddenValues []string
+}
+
+// Filter shows filter dropdowns on the first row of Range, and hides the rows
+// that don't match Criteria, keyed by column index in the sheet.
+type Filter struct {
+	Range    CellRange
+	Criteria map[int]ColumnCriteria
+	Sort     []SortSpec
+}
+
+// FilterView is a named filter that users can toggle without affecting what
+// other users see.
+type FilterView struct {
+	ID    int64
+	Title string
+
+	Filter
+}
+
+// SetBasicFilter sets the sheet's filter, replacing any existing one.
+func (s *Sheet) SetBasicFilter(f Filter) error {
+	if err := f.checkSort(); err != nil {
+		return err
+	}
+
+	_, err := s.doBatch(setBasicFilterRequest(s.Properties.SheetId, f))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't set filter on %s", s.Title())
+	}
+
+	return nil
+}
+
+// ClearBasicFilter removes the sheet's filter, showing every row.
+func (s *Sheet) ClearBasicFilter() error {
+	_, err := s.doBatch(&sheets.Request{
+		ClearBasicFilter: &sheets.ClearBasicFilterRequest{SheetId: s.Properties.SheetId},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't clear filter on %s", s.Title())
+	}
+
+	return nil
+}
+
+// GetBasicFilter returns the sheet's filter, or nil if it has none.
+func (s *Sheet) GetBasicFilter() *Filter {
+	s.refresh()
+
+	if s.Sheet.BasicFilter == nil {
+		return nil
+	}
+
+	f := filterFromAPI(s.Sheet.BasicFilter.Range, s.Sheet.BasicFilter.Criteria,
+		s.Sheet.BasicFilter.SortSpecs, s.Properties.GridProperties)
+	return &f
+}
+
+// ListFilterViews returns the sheet's filter views.
+func (s *Sheet) ListFilterViews() []*FilterView {
+	s.refresh()
+
+	views := make([]*FilterView, len(s.Sheet.FilterViews))
+	for i, view := range s.Sheet.FilterViews {
+		views[i] = &FilterView{
+			ID:     view.FilterViewId,
+			Title:  view.Title,
+			Filter: filterFromAPI(view.Range, view.Criteria, view.SortSpecs, s.Properties.GridProperties),
+		}
+	}
+
+	return views
+}
+
+// AddFilterView adds a filter view called title and returns it.
+func (s *Sheet) AddFilterView(title string, f Filter) (*FilterView, error) {
+	if err := f.checkSort(); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.doBatch(addFilterViewRequest(s.Properties.SheetId, title, f))
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't add filter view %s to %s", title, s.Title())
+	}
+
+	if len(resp.Replies) == 0 || resp.Replies[0].AddFilterView == nil || resp.Replies[0].AddFilterView.Filter == nil {
+		return nil, errors.New("filter view missing from response")
+	}
+
+	return &FilterView{
+		ID:     resp.Replies[0].AddFilterView.Filter.FilterViewId,
+		Title:  title,
+		Filter: f,
+	}, nil
+}
+
+// UpdateFilterView replaces the title, range, criteria and sort of the filter
+// view identified by view.ID.
+func (s *Sheet) UpdateFilterView(view *FilterView) error {
+	if err := view.Filter.checkSort(); err != nil {
+		return err
+	}
+
+	apiView := view.Filter.apiFilterView(s.Properties.SheetId)
+	apiView.FilterViewId = view.ID
+	apiView.Title = view.Title
+
+	_, err := s.doBatch(&sheets.Request{
+		UpdateFilterView: &sheets.UpdateFilterViewRequest{
+			Filter: apiView,
+			Fields: "title,range,criteria,sortSpecs",
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update filter view %d of %s", view.ID, s.Title())
+	}
+
+	return nil
+}
+
+func (s *Sheet) DeleteFilterView(id int64) error {
+	_, err := s.doBatch(&sheets.Request{
+		DeleteFilterView: &sheets.DeleteFilterViewRequest{FilterId: id},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete filter view %d of %s", id, s.Title())
+	}
+
+	return nil
+}
+
+// SetBasicFilter sets the filter of the sheet titled title.
+func (b *Batch) SetBasicFilter(title string, f Filter) *Batch {
+	if err := f.checkSort(); err != nil {
+		return b.fail(err)
+	}
+
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(setBasicFilterRequest(id, f))
+}
+
+// AddFilterView adds a filter view called viewTitle to the sheet titled
+// title. Its ID is in the operation's result.
+func (b *Batch) AddFilterView(title, viewTitle string, f Filter) *Batch {
+	if err := f.checkSort(); err != nil {
+		return b.fail(err)
+	}
+
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(addFilterViewRequest(id, viewTitle, f))
+}
+
+func setBasicFilterRequest(sheetID int64, f Filter) *sheets.Request {
+	view := f.apiFilterView(sheetID)
+
+	return &sheets.Request{
+		SetBasicFilter: &sheets.SetBasicFilterRequest{
+			Filter: &sheets.BasicFilter{
+				Range:     view.Range,
+				Criteria:  view.Criteria,
+				SortSpecs: view.SortSpecs,
+			},
+		},
+	}
+}
+
+func addFilterViewRequest(sheetID int64, title string, f Filter) *sheets.Request {
+	view := f.apiFilterView(sheetID)
+	view.Title = title
+
+	return &sheets.Request{
+		AddFilterView: &sheets.AddFilterViewRequest{Filter: view},
+	}
+}
+
+// checkSort returns an error if the filter sorts by header, as filters don't
+// resolve headers.
+func (f Filter) checkSort() error {
+	for _, spec := range f.Sort {
+		if spec.Header != "" {
+			return errors.Errorf("filters can't sort by header %s, use the column index", spec.Header)
+		}
+	}
+
+	return nil
+}
+
+func (f Filter) apiFilterView(sheetID int64) *sheets.FilterView {
+	view := &sheets.FilterView{
+		Range:     f.Range.GridRange(sheetID),
+		SortSpecs: apiSortSpecs(f.Sort),
+	}
+
+	if len(f.Criteria) > 0 {
+		view.Criteria = map[string]sheets.FilterCriteria{}
+		for col, criteria := range f.Criteria {
+			apiCriteria := sheets.FilterCriteria{HiddenValues: criteria.HiddenValues}
+			if criteria.Condition != nil {
+				apiCriteria.Condition = criteria.Condition.booleanCondition()
+			}
+			view.Criteria[strconv.Itoa(col)] = apiCriteria
+		}
+	}
+
+	return view
+}
+
+func apiSortSpecs(specs []SortSpec) []*sheets.SortSpec {
+	var apiSpecs []*sheets.SortSpec
+	for _, spec := range specs {
+		order := "ASCENDING"
+		if spec.Descending {
+			order = "DESCENDING"
+		}
+
+		apiSpecs = append(apiSpecs, &sheets.SortSpec{
+			DimensionIndex: int64(spec.Column),
+			SortOrder:      order,
+			// Column A is index 0
+			ForceSendFields: []string{"DimensionIndex"},
+		})
+	}
+
+	return apiSpecs
+}
+
+func filterFromAPI(gridRange *sheets.GridRange, criteria map[string]sheets.FilterCriteria,
+	specs []*sheets.SortSpec, grid *sheets.GridProperties) Filter {
+	f := Filter{}
+	if gridRange != nil {
+		f.Range = cellRangeFromGrid(gridRange, grid)
+	}
+
+	for key, apiCriteria := range criteria {
+		col, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+
+		c := ColumnCriteria{HiddenValues: apiCriteria.HiddenValues}
+		if apiCriteria.Condition != nil {
+			cond := conditionFromAPI(apiCriteria.Condition)
+			c.Condition = &cond
+		}
+
+		if f.Criteria == nil {
+			f.Criteria = map[int]ColumnCriteria{}
+		}
+		f.Criteria[col] = c
+	}
+
+	for _, spec := range specs {
+		f.Sort = append(f.Sort, SortSpec{
+			Column:     int(spec.DimensionIndex),
+			Descending: spec.SortOrder == "DESCENDING",
+		})
+	}
+
+	return f
+}
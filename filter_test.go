@@ -0,0 +1,119 @@
+package sheets
+
+import (
+	"encoding/json"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestSetBasicFilterRequest(t *testing.T) {
+	cond := NumberGreater(10)
+	f := Filter{
+		Range:    CellRange{CellPos{0, 0}, CellPos{99, 3}},
+		Criteria: map[int]ColumnCriteria{2: {Condition: &cond}, 3: {HiddenValues: []string{"n/a"}}},
+		Sort:     []SortSpec{{Column: 0}, {Column: 2, Descending: true}},
+	}
+
+	encoded, err := json.Marshal(setBasicFilterRequest(7, f))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"setBasicFilter":{"filter":{"criteria":{"2":{"condition":{"type":"NUMBER_GREATER","values":[{"userEnteredValue":"10"}]}},"3":{"hiddenValues":["n/a"]}},` +
+		`"range":{"endColumnIndex":4,"endRowIndex":100,"sheetId":7},` +
+		`"sortSpecs":[{"dimensionIndex":0,"sortOrder":"ASCENDING"},{"dimensionIndex":2,"sortOrder":"DESCENDING"}]}}}`
+	if string(encoded) != expected {
+		t.Errorf("Wanted %s, but got %s", expected, encoded)
+	}
+}
+
+func TestFilterRoundTrip(t *testing.T) {
+	cond := TextContains("late")
+	f := Filter{
+		Range:    CellRange{CellPos{0, 0}, CellPos{9, 1}},
+		Criteria: map[int]ColumnCriteria{1: {Condition: &cond}},
+		Sort:     []SortSpec{{Column: 1, Descending: true}},
+	}
+
+	view := f.apiFilterView(7)
+	got := filterFromAPI(view.Range, view.Criteria, view.SortSpecs, &sheets.GridProperties{RowCount: 10, ColumnCount: 2})
+
+	if got.Range != f.Range {
+		t.Errorf("Wanted %v, but got %v", f.Range, got.Range)
+	}
+	if c := got.Criteria[1].Condition; c == nil || c.Type != "TEXT_CONTAINS" || c.Values[0] != "late" {
+		t.Errorf("Wanted %v, but got %v", cond, c)
+	}
+	if len(got.Sort) != 1 || got.Sort[0] != f.Sort[0] {
+		t.Errorf("Wanted %v, but got %v", f.Sort, got.Sort)
+	}
+}
+
+func TestApplyFiltersLocally(t *testing.T) {
+	ss := cacheTestSpreadsheet()
+	f := Filter{Range: CellRange{CellPos{0, 0}, CellPos{9, 1}}}
+
+	ss.applyLocally([]*sheets.Request{
+		setBasicFilterRequest(1, f),
+		addFilterViewRequest(1, "Late", f),
+	}, []*sheets.Response{
+		{},
+		{AddFilterView: &sheets.AddFilterViewResponse{Filter: &sheets.FilterView{
+			FilterViewId: 5, Title: "Late", Range: f.Range.GridRange(1),
+		}}},
+	})
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+
+	sheet := ss.SheetByID(1)
+	if got := sheet.GetBasicFilter(); got == nil || got.Range != f.Range {
+		t.Errorf("Wanted filter on %v, but got %v", f.Range, got)
+	}
+	if views := sheet.ListFilterViews(); len(views) != 1 || views[0].ID != 5 {
+		t.Errorf("Wanted filter view 5, but got %v", views)
+	}
+
+	ss.applyLocally([]*sheets.Request{
+		{ClearBasicFilter: &sheets.ClearBasicFilterRequest{SheetId: 1}},
+		{DeleteFilterView: &sheets.DeleteFilterViewRequest{FilterId: 5}},
+	}, nil)
+	if sheet.GetBasicFilter() != nil || len(sheet.ListFilterViews()) != 0 {
+		t.Error("Expected the filter and filter view to be removed")
+	}
+
+	// Replies without the filter view leave the cache stale
+	ss.applyLocally([]*sheets.Request{addFilterViewRequest(1, "Late", f)},
+		[]*sheets.Response{{AddFilterView: &sheets.AddFilterViewResponse{}}})
+	if !ss.Stale() {
+		t.Error("Expected the cache to be stale")
+	}
+}
+
+func TestFilterRejectsHeaderSort(t *testing.T) {
+	f := Filter{
+		Range: CellRange{CellPos{0, 0}, CellPos{9, 1}},
+		Sort:  []SortSpec{{Header: "Date"}},
+	}
+
+	sheet := &Sheet{&sheets.Sheet{Properties: &sheets.SheetProperties{SheetId: 7, Title: "Data"}}, testSpreadsheet(nil), nil}
+	if err := sheet.SetBasicFilter(f); err == nil {
+		t.Error("Expected an error setting a filter sorted by header")
+	}
+	if _, err := sheet.AddFilterView("View", f); err == nil {
+		t.Error("Expected an error adding a filter view sorted by header")
+	}
+	if err := sheet.UpdateFilterView(&FilterView{ID: 1, Filter: f}); err == nil {
+		t.Error("Expected an error updating a filter view sorted by header")
+	}
+
+	b := testSpreadsheet(nil).NewBatch().SetBasicFilter("Data", f)
+	if b.err == nil {
+		t.Error("Expected the batch to fail")
+	}
+	b = testSpreadsheet(nil).NewBatch().AddFilterView("Data", "View", f)
+	if b.err == nil {
+		t.Error("Expected the batch to fail")
+	}
+}
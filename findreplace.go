@@ -0,0 +1,116 @@
+package sheets
+
+import (
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// FindReplace replaces the occurrences of Find with Replacement in cell
+// values.
+type FindReplace struct {
+	Find        string
+	Replacement string
+
+	// Regex treats Find as a regular expression. Replacement can then refer
+	// to capture groups, e.g. "$1".
+	Regex bool
+	// MatchCase makes the search case-sensitive
+	MatchCase bool
+	// WholeCell only matches cells whose whole value is Find
+	WholeCell bool
+	// IncludeFormulas also searches formulas, not only values
+	IncludeFormulas bool
+}
+
+// FindReplaceResult counts the changes made by a FindReplace.
+type FindReplaceResult struct {
+	Occurrences     int
+	ValuesChanged   int
+	FormulasChanged int
+	RowsChanged     int
+	SheetsChanged   int
+}
+
+// FindReplace replaces fr.Find in every sheet of the spreadsheet.
+func (s *Spreadsheet) FindReplace(fr FindReplace) (*FindReplaceResult, error) {
+	req := fr.request()
+	req.AllSheets = true
+
+	resp, err := s.DoBatch(&sheets.Request{FindReplace: req})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't replace %s", fr.Find)
+	}
+
+	return findReplaceResult(resp), nil
+}
+
+// FindReplace replaces fr.Find in the sheet.
+func (s *Sheet) FindReplace(fr FindReplace) (*FindReplaceResult, error) {
+	req := fr.request()
+	req.SheetId = s.Properties.SheetId
+	req.ForceSendFields = append(req.ForceSendFields, "SheetId")
+
+	resp, err := s.doBatch(&sheets.Request{FindReplace: req})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't replace %s in %s", fr.Find, s.Title())
+	}
+
+	return findReplaceResult(resp), nil
+}
+
+// FindReplaceRange replaces fr.Find in the cells of r.
+func (s *Sheet) FindReplaceRange(r CellRange, fr FindReplace) (*FindReplaceResult, error) {
+	req := fr.request()
+	req.Range = r.GridRange(s.Properties.SheetId)
+
+	resp, err := s.doBatch(&sheets.Request{FindReplace: req})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't replace %s in %s!%s", fr.Find, s.Title(), r.String())
+	}
+
+	return findReplaceResult(resp), nil
+}
+
+// FindReplace replaces fr.Find in every sheet of the spreadsheet. Its counts
+// are in the operation's result.
+func (b *Batch) FindReplace(fr FindReplace) *Batch {
+	req := fr.request()
+	req.AllSheets = true
+
+	return b.Add(&sheets.Request{FindReplace: req})
+}
+
+func (fr FindReplace) request() *sheets.FindReplaceRequest {
+	return &sheets.FindReplaceRequest{
+		Find:            fr.Find,
+		Replacement:     fr.Replacement,
+		SearchByRegex:   fr.Regex,
+		MatchCase:       fr.MatchCase,
+		MatchEntireCell: fr.WholeCell,
+		IncludeFormulas: fr.IncludeFormulas,
+		// An empty replacement deletes the matches
+		ForceSendFields: []string{"Replacement"},
+	}
+}
+
+func findReplaceResult(resp *sheets.BatchUpdateSpreadsheetResponse) *FindReplaceResult {
+	if resp == nil || len(resp.Replies) == 0 {
+		return &FindReplaceResult{}
+	}
+
+	return findReplaceResultFromAPI(resp.Replies[0].FindReplace)
+}
+
+func findReplaceResultFromAPI(reply *sheets.FindReplaceResponse) *FindReplaceResult {
+	if reply == nil {
+		return &FindReplaceResult{}
+	}
+
+	return &FindReplaceResult{
+		Occurrences:     int(reply.OccurrencesChanged),
+		ValuesChanged:   int(reply.ValuesChanged),
+		FormulasChanged: int(reply.FormulasChanged),
+		RowsChanged:     int(reply.RowsChanged),
+		SheetsChanged:   int(reply.SheetsChanged),
+	}
+}
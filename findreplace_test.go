@@ -0,0 +1,53 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestFindReplaceRequest(t *testing.T) {
+	// Raw requests, as forced zero fields are lost when decoding
+	var got []json.RawMessage
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []struct {
+				FindReplace json.RawMessage
+			}
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req.Requests[0].FindReplace)
+
+		json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{
+			Replies: []*sheets.Response{{FindReplace: &sheets.FindReplaceResponse{
+				OccurrencesChanged: 3, ValuesChanged: 2, SheetsChanged: 1,
+			}}},
+		})
+	}))
+	client.LightweightBatches = true
+	ss := testSpreadsheet(client)
+
+	res, err := ss.FindReplace(FindReplace{Find: `\{\{name\}\}`, Replacement: "Acme", Regex: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Occurrences != 3 || res.ValuesChanged != 2 || res.SheetsChanged != 1 {
+		t.Errorf("Unexpected result %+v", res)
+	}
+
+	if _, err := ss.GetSheet("Sheet1").FindReplace(FindReplace{Find: "x", WholeCell: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"allSheets":true,"find":"\\{\\{name\\}\\}","replacement":"Acme","searchByRegex":true}`
+	if string(got[0]) != expected {
+		t.Errorf("Wanted %s, but got %s", expected, got[0])
+	}
+
+	expected = `{"find":"x","matchEntireCell":true,"replacement":"","sheetId":0}`
+	if string(got[1]) != expected {
+		t.Errorf("Wanted %s, but got %s", expected, got[1])
+	}
+}
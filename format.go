@@ -0,0 +1,313 @@
+package sheets
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+type HorizontalAlignment string
+
+const (
+	AlignLeft   HorizontalAlignment = "LEFT"
+	AlignCenter HorizontalAlignment = "CENTER"
+	AlignRight  HorizontalAlignment = "RIGHT"
+)
+
+type VerticalAlignment string
+
+const (
+	AlignTop    VerticalAlignment = "TOP"
+	AlignMiddle VerticalAlignment = "MIDDLE"
+	AlignBottom VerticalAlignment = "BOTTOM"
+)
+
+// WrapStrategy controls how text that doesn't fit in a cell is displayed.
+type WrapStrategy string
+
+const (
+	WrapOverflow WrapStrategy = "OVERFLOW_CELL"
+	WrapClip     WrapStrategy = "CLIP"
+	WrapText     WrapStrategy = "WRAP"
+)
+
+// BorderStyle is the line style of a border.
+type BorderStyle string
+
+const (
+	BorderNone        BorderStyle = "NONE"
+	BorderSolid       BorderStyle = "SOLID"
+	BorderSolidMedium BorderStyle = "SOLID_MEDIUM"
+	BorderSolidThick  BorderStyle = "SOLID_THICK"
+	BorderDashed      BorderStyle = "DASHED"
+	BorderDotted      BorderStyle = "DOTTED"
+	BorderDouble      BorderStyle = "DOUBLE"
+)
+
+// NewBorder returns a border with the given style and color. A nil color is
+// black.
+func NewBorder(style BorderStyle, color *sheets.Color) *sheets.Border {
+	if color == nil {
+		color = RGB(0, 0, 0)
+	}
+
+	return &sheets.Border{Style: string(style), Color: color}
+}
+
+// Style is a set of formatting changes for a range of cells. Only the
+// properties set on the style are changed when it is applied, the cells keep
+// their other formatting.
+//
+//	header := NewStyle().Bold(true).Background(RGB(230, 230, 230)).Outline(NewBorder(BorderSolid, nil))
+//	err := sheet.Format(headerRange, header)
+type Style struct {
+	format sheets.CellFormat
+	fields []string
+
+	outline *sheets.Border
+	inner   *sheets.Border
+}
+
+func NewStyle() *Style {
+	return &Style{}
+}
+
+// NumberFormat sets how numbers and dates are displayed. formatType is one of
+// the API's NumberFormat types (NUMBER, CURRENCY, PERCENT, DATE, ...) and
+// pattern a format pattern such as "#,##0.00" or "yyyy-mm-dd", or empty to
+// use the spreadsheet locale's default.
+func (st *Style) NumberFormat(formatType, pattern string) *Style {
+	st.format.NumberFormat = &sheets.NumberFormat{Type: formatType, Pattern: pattern}
+	return st.set("numberFormat")
+}
+
+func (st *Style) Number(pattern string) *Style {
+	return st.NumberFormat("NUMBER", pattern)
+}
+
+func (st *Style) Currency(pattern string) *Style {
+	return st.NumberFormat("CURRENCY", pattern)
+}
+
+func (st *Style) Percent(pattern string) *Style {
+	return st.NumberFormat("PERCENT", pattern)
+}
+
+func (st *Style) Date(pattern string) *Style {
+	return st.NumberFormat("DATE", pattern)
+}
+
+func (st *Style) DateTime(pattern string) *Style {
+	return st.NumberFormat("DATE_TIME", pattern)
+}
+
+func (st *Style) Text() *Style {
+	return st.NumberFormat("TEXT", "")
+}
+
+func (st *Style) Bold(bold bool) *Style {
+	st.textFormat().Bold = bold
+	return st.set("textFormat.bold")
+}
+
+func (st *Style) Italic(italic bool) *Style {
+	st.textFormat().Italic = italic
+	return st.set("textFormat.italic")
+}
+
+func (st *Style) Underline(underline bool) *Style {
+	st.textFormat().Underline = underline
+	return st.set("textFormat.underline")
+}
+
+func (st *Style) Strikethrough(strikethrough bool) *Style {
+	st.textFormat().Strikethrough = strikethrough
+	return st.set("textFormat.strikethrough")
+}
+
+func (st *Style) FontFamily(family string) *Style {
+	st.textFormat().FontFamily = family
+	return st.set("textFormat.fontFamily")
+}
+
+func (st *Style) FontSize(size int) *Style {
+	st.textFormat().FontSize = int64(size)
+	return st.set("textFormat.fontSize")
+}
+
+func (st *Style) TextColor(color *sheets.Color) *Style {
+	st.textFormat().ForegroundColor = color
+	return st.set("textFormat.foregroundColor")
+}
+
+func (st *Style) Background(color *sheets.Color) *Style {
+	st.format.BackgroundColor = color
+	return st.set("backgroundColor")
+}
+
+func (st *Style) HorizontalAlign(align HorizontalAlignment) *Style {
+	st.format.HorizontalAlignment = string(align)
+	return st.set("horizontalAlignment")
+}
+
+func (st *Style) VerticalAlign(align VerticalAlignment) *Style {
+	st.format.VerticalAlignment = string(align)
+	return st.set("verticalAlignment")
+}
+
+func (st *Style) Wrap(strategy WrapStrategy) *Style {
+	st.format.WrapStrategy = string(strategy)
+	return st.set("wrapStrategy")
+}
+
+// Borders sets the border of every side of each cell.
+func (st *Style) Borders(border *sheets.Border) *Style {
+	st.format.Borders = &sheets.Borders{Top: border, Bottom: border, Left: border, Right: border}
+	return st.set("borders")
+}
+
+// Outline draws border around the range as a whole.
+func (st *Style) Outline(border *sheets.Border) *Style {
+	st.outline = border
+	return st
+}
+
+// InnerBorders draws border between the cells of the range.
+func (st *Style) InnerBorders(border *sheets.Border) *Style {
+	st.inner = border
+	return st
+}
+
+// CellFormat returns the format set by the style and the field mask of the
+// properties it sets, as expected by RepeatCellRequest.
+func (st *Style) CellFormat() (*sheets.CellFormat, string) {
+	format := st.format
+	if format.TextFormat != nil {
+		// Don't let later changes to the style leak in batched requests
+		textFormat := *format.TextFormat
+		format.TextFormat = &textFormat
+	}
+
+	fields := make([]string, len(st.fields))
+	for i, f := range st.fields {
+		fields[i] = "userEnteredFormat." + f
+	}
+
+	return &format, strings.Join(fields, ",")
+}
+
+func (st *Style) textFormat() *sheets.TextFormat {
+	if st.format.TextFormat == nil {
+		st.format.TextFormat = &sheets.TextFormat{}
+	}
+
+	return st.format.TextFormat
+}
+
+func (st *Style) set(field string) *Style {
+	for _, f := range st.fields {
+		if f == field {
+			return st
+		}
+	}
+	st.fields = append(st.fields, field)
+
+	return st
+}
+
+func (st *Style) requests(gridRange *sheets.GridRange) []*sheets.Request {
+	var requests []*sheets.Request
+
+	if format, fields := st.CellFormat(); fields != "" {
+		requests = append(requests, &sheets.Request{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Range:  gridRange,
+				Cell:   &sheets.CellData{UserEnteredFormat: format},
+				Fields: fields,
+			},
+		})
+	}
+
+	if st.outline != nil || st.inner != nil {
+		requests = append(requests, &sheets.Request{
+			UpdateBorders: &sheets.UpdateBordersRequest{
+				Range:           gridRange,
+				Top:             st.outline,
+				Bottom:          st.outline,
+				Left:            st.outline,
+				Right:           st.outline,
+				InnerHorizontal: st.inner,
+				InnerVertical:   st.inner,
+			},
+		})
+	}
+
+	return requests
+}
+
+// Format applies style to the cells of r in a single batchUpdate. Use a Batch
+// to format several ranges at once.
+func (s *Sheet) Format(r CellRange, style *Style) error {
+	requests := style.requests(r.GridRange(s.Properties.SheetId))
+	if len(requests) == 0 {
+		return nil
+	}
+
+	_, err := s.doBatch(requests...)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't format %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// AutoResizeColumns resizes count columns starting at start to fit their
+// contents.
+func (s *Sheet) AutoResizeColumns(start, count int) error {
+	_, err := s.doBatch(autoResizeRequest(s.Properties.SheetId, DimensionColumns, start, count))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't resize columns of %s", s.Title())
+	}
+
+	return nil
+}
+
+// AutoResizeRows resizes count rows starting at start to fit their contents.
+func (s *Sheet) AutoResizeRows(start, count int) error {
+	_, err := s.doBatch(autoResizeRequest(s.Properties.SheetId, DimensionRows, start, count))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't resize rows of %s", s.Title())
+	}
+
+	return nil
+}
+
+// Style applies style to the cells of r.
+func (b *Batch) Style(r SheetRange, style *Style) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(style.requests(gridRange)...)
+}
+
+// AutoResize resizes count rows or columns starting at start to fit their
+// contents.
+func (b *Batch) AutoResize(title string, dim Dimension, start, count int) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	return b.Add(autoResizeRequest(id, dim, start, count))
+}
+
+func autoResizeRequest(sheetID int64, dim Dimension, start, count int) *sheets.Request {
+	return &sheets.Request{
+		AutoResizeDimensions: &sheets.AutoResizeDimensionsRequest{
+			Dimensions: dimensionRange(sheetID, dim, start, count),
+		},
+	}
+}
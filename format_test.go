@@ -0,0 +1,55 @@
+package sheets
+
+import (
+	"testing"
+)
+
+func TestStyleCellFormat(t *testing.T) {
+	style := NewStyle().
+		Currency("$#,##0.00").
+		Bold(true).
+		FontSize(12).
+		Bold(false).
+		Background(RGB(255, 0, 0)).
+		HorizontalAlign(AlignCenter)
+
+	format, fields := style.CellFormat()
+
+	expected := "userEnteredFormat.numberFormat,userEnteredFormat.textFormat.bold," +
+		"userEnteredFormat.textFormat.fontSize,userEnteredFormat.backgroundColor," +
+		"userEnteredFormat.horizontalAlignment"
+	if fields != expected {
+		t.Errorf("Wanted fields %s, but got %s", expected, fields)
+	}
+
+	if format.NumberFormat.Type != "CURRENCY" || format.TextFormat.Bold || format.TextFormat.FontSize != 12 ||
+		format.BackgroundColor.Red != 1 || format.BackgroundColor.Green != 0 || format.HorizontalAlignment != "CENTER" {
+		t.Errorf("Unexpected format %+v", format)
+	}
+
+	style.Italic(true)
+	if format.TextFormat.Italic {
+		t.Error("Expected the returned format to be independent from the style")
+	}
+}
+
+func TestStyleRequests(t *testing.T) {
+	gridRange := CellRange{CellPos{0, 0}, CellPos{0, 3}}.GridRange(3)
+
+	if got := NewStyle().requests(gridRange); len(got) != 0 {
+		t.Errorf("Wanted no requests for an empty style, but got %d", len(got))
+	}
+
+	got := NewStyle().Bold(true).Outline(NewBorder(BorderSolid, nil)).requests(gridRange)
+	if len(got) != 2 || got[0].RepeatCell == nil || got[1].UpdateBorders == nil {
+		t.Fatalf("Wanted a format and a borders request, but got %+v", got)
+	}
+	if borders := got[1].UpdateBorders; borders.Top.Style != "SOLID" || borders.InnerVertical != nil {
+		t.Errorf("Unexpected borders %+v", borders)
+	}
+
+	got = NewStyle().InnerBorders(NewBorder(BorderDotted, nil)).requests(gridRange)
+	if len(got) != 1 || got[0].UpdateBorders == nil || got[0].UpdateBorders.Top != nil {
+		t.Errorf("Wanted only inner borders, but got %+v", got)
+	}
+}
@@ -0,0 +1,155 @@
+package sheets
+
+import (
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// MergeType controls how the cells of a range are merged.
+type MergeType string
+
+const (
+	// MergeAll merges the whole range in a single cell.
+	MergeAll MergeType = "MERGE_ALL"
+	// MergeRows merges each row of the range.
+	MergeRows MergeType = "MERGE_ROWS"
+	// MergeColumns merges each column of the range.
+	MergeColumns MergeType = "MERGE_COLUMNS"
+)
+
+// Merge merges the cells of r. Only the value of the top left cell of each
+// merge is kept.
+func (s *Sheet) Merge(r CellRange, mergeType MergeType) error {
+	_, err := s.doBatch(mergeRequest(r.GridRange(s.Properties.SheetId), mergeType))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't merge %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// Unmerge unmerges every merge within r. Merges that are only partially in r
+// are an error.
+func (s *Sheet) Unmerge(r CellRange) error {
+	_, err := s.doBatch(unmergeRequest(r.GridRange(s.Properties.SheetId)))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't unmerge %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// ListMerges returns the merged ranges of the sheet.
+func (s *Sheet) ListMerges() []CellRange {
+	s.refresh()
+
+	merges := make([]CellRange, len(s.Sheet.Merges))
+	for i, merge := range s.Sheet.Merges {
+		merges[i] = cellRangeFromGrid(merge, s.Properties.GridProperties)
+	}
+
+	return merges
+}
+
+// IsHiddenByMerge reports whether pos is hidden by a merge, i.e. part of a
+// merge but not its top left cell. Values written to hidden cells are kept
+// but not displayed. Use HiddenByMerge to check many cells.
+func (s *Sheet) IsHiddenByMerge(pos CellPos) bool {
+	return s.HiddenByMerge()(pos)
+}
+
+// HiddenByMerge returns a function reporting whether a cell is hidden by a
+// merge, as IsHiddenByMerge. The sheet's merges are read once, when
+// HiddenByMerge is called.
+func (s *Sheet) HiddenByMerge() func(pos CellPos) bool {
+	merges := s.ListMerges()
+
+	return func(pos CellPos) bool {
+		for _, merge := range merges {
+			if merge.Contains(pos) && pos != merge.Start {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Contains reports whether pos is in the range.
+func (a CellRange) Contains(pos CellPos) bool {
+	return pos.Row >= a.Start.Row && pos.Row <= a.End.Row &&
+		pos.Col >= a.Start.Col && pos.Col <= a.End.Col
+}
+
+func mergeRequest(gridRange *sheets.GridRange, mergeType MergeType) *sheets.Request {
+	return &sheets.Request{
+		MergeCells: &sheets.MergeCellsRequest{
+			Range:     gridRange,
+			MergeType: string(mergeType),
+		},
+	}
+}
+
+func unmergeRequest(gridRange *sheets.GridRange) *sheets.Request {
+	return &sheets.Request{
+		UnmergeCells: &sheets.UnmergeCellsRequest{Range: gridRange},
+	}
+}
+
+// applyMerge adds the merges created by req to the cached sheet, and reports
+// whether they're known.
+func applyMerge(sheet *sheets.Sheet, req *sheets.MergeCellsRequest) bool {
+	rng := req.Range
+	if rng.EndRowIndex == 0 || rng.EndColumnIndex == 0 {
+		// Unbounded ranges depend on the grid size
+		return false
+	}
+
+	switch MergeType(req.MergeType) {
+	case MergeAll:
+		merge := *rng
+		sheet.Merges = append(sheet.Merges, &merge)
+	case MergeRows:
+		if rng.EndColumnIndex-rng.StartColumnIndex < 2 {
+			return true
+		}
+		for row := rng.StartRowIndex; row < rng.EndRowIndex; row++ {
+			merge := *rng
+			merge.StartRowIndex, merge.EndRowIndex = row, row+1
+			sheet.Merges = append(sheet.Merges, &merge)
+		}
+	case MergeColumns:
+		if rng.EndRowIndex-rng.StartRowIndex < 2 {
+			return true
+		}
+		for col := rng.StartColumnIndex; col < rng.EndColumnIndex; col++ {
+			merge := *rng
+			merge.StartColumnIndex, merge.EndColumnIndex = col, col+1
+			sheet.Merges = append(sheet.Merges, &merge)
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// applyUnmerge removes the merges within req's range from the cached sheet.
+func applyUnmerge(sheet *sheets.Sheet, req *sheets.UnmergeCellsRequest) bool {
+	rng := req.Range
+	if rng.EndRowIndex == 0 || rng.EndColumnIndex == 0 {
+		return false
+	}
+
+	var kept []*sheets.GridRange
+	for _, merge := range sheet.Merges {
+		inside := merge.StartRowIndex >= rng.StartRowIndex && merge.EndRowIndex <= rng.EndRowIndex &&
+			merge.StartColumnIndex >= rng.StartColumnIndex && merge.EndColumnIndex <= rng.EndColumnIndex
+		if !inside {
+			kept = append(kept, merge)
+		}
+	}
+	sheet.Merges = kept
+
+	return true
+}
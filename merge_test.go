@@ -0,0 +1,59 @@
+package sheets
+
+import (
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestApplyMergesLocally(t *testing.T) {
+	ss := cacheTestSpreadsheet()
+	grid := func(r CellRange) *sheets.GridRange { return r.GridRange(1) }
+
+	ss.applyLocally([]*sheets.Request{
+		mergeRequest(grid(CellRange{CellPos{0, 0}, CellPos{0, 3}}), MergeAll),
+		mergeRequest(grid(CellRange{CellPos{2, 0}, CellPos{3, 1}}), MergeRows),
+		mergeRequest(grid(CellRange{CellPos{5, 0}, CellPos{6, 1}}), MergeColumns),
+		unmergeRequest(grid(CellRange{CellPos{5, 0}, CellPos{6, 0}})),
+	}, nil)
+
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+
+	sheet := ss.SheetByID(1)
+	expected := []CellRange{
+		{CellPos{0, 0}, CellPos{0, 3}},
+		{CellPos{2, 0}, CellPos{2, 1}},
+		{CellPos{3, 0}, CellPos{3, 1}},
+		{CellPos{5, 1}, CellPos{6, 1}},
+	}
+	merges := sheet.ListMerges()
+	if len(merges) != len(expected) {
+		t.Fatalf("Wanted %v, but got %v", expected, merges)
+	}
+	for i := range expected {
+		if merges[i] != expected[i] {
+			t.Errorf("Wanted %v, but got %v", expected[i], merges[i])
+		}
+	}
+
+	hidden := sheet.HiddenByMerge()
+	for _, tt := range []struct {
+		pos    CellPos
+		hidden bool
+	}{
+		{CellPos{0, 0}, false},
+		{CellPos{0, 2}, true},
+		{CellPos{1, 2}, false},
+		{CellPos{6, 1}, true},
+		{CellPos{6, 0}, false},
+	} {
+		if got := sheet.IsHiddenByMerge(tt.pos); got != tt.hidden {
+			t.Errorf("Wanted %v hidden %v, but got %v", tt.pos, tt.hidden, got)
+		}
+		if got := hidden(tt.pos); got != tt.hidden {
+			t.Errorf("Wanted %v hidden %v, but got %v from HiddenByMerge", tt.pos, tt.hidden, got)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package sheets
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// NamedRange is a range of a sheet referred to by name, in formulas or in
+// place of a SheetRange with ByName.
+type NamedRange struct {
+	ID    string
+	Name  string
+	Range SheetRange
+}
+
+// ByName returns a SheetRange referring to the named range name. It can be
+// used wherever a SheetRange is expected.
+//
+//	values, err := spreadsheet.GetRange(ByName("Totals"))
+func ByName(name string) SheetRange {
+	return SheetRange{Name: name}
+}
+
+// ListNamedRanges returns the spreadsheet's named ranges.
+func (s *Spreadsheet) ListNamedRanges() []*NamedRange {
+	var ranges []*NamedRange
+	for _, named := range s.NamedRanges {
+		if r := s.namedRangeFromAPI(named); r != nil {
+			ranges = append(ranges, r)
+		}
+	}
+
+	return ranges
+}
+
+// GetNamedRange returns the named range called name, or nil if there is
+// none. Names are case-insensitive.
+func (s *Spreadsheet) GetNamedRange(name string) *NamedRange {
+	named := s.namedRange(name)
+	if named == nil {
+		return nil
+	}
+
+	return s.namedRangeFromAPI(named)
+}
+
+// AddNamedRange names r. Names must start with a letter or an underscore, and
+// can't look like a cell reference.
+func (s *Spreadsheet) AddNamedRange(name string, r SheetRange) (*NamedRange, error) {
+	gridRange, err := s.gridRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.DoBatch(&sheets.Request{
+		AddNamedRange: &sheets.AddNamedRangeRequest{
+			NamedRange: &sheets.NamedRange{Name: name, Range: gridRange},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't add named range %s", name)
+	}
+
+	if len(resp.Replies) == 0 || resp.Replies[0].AddNamedRange == nil || resp.Replies[0].AddNamedRange.NamedRange == nil {
+		return nil, errors.New("named range missing from response")
+	}
+
+	named := s.namedRangeFromAPI(resp.Replies[0].AddNamedRange.NamedRange)
+	if named == nil {
+		return nil, errors.Errorf("named range %s refers to an unknown sheet", name)
+	}
+
+	return named, nil
+}
+
+// DeleteNamedRange deletes the named range called name. The cells it refers
+// to are left untouched, but formulas using the name break.
+func (s *Spreadsheet) DeleteNamedRange(name string) error {
+	if err := s.refreshIfStale(); err != nil {
+		return err
+	}
+
+	named := s.namedRange(name)
+	if named == nil {
+		return errors.Errorf("named range %s does not exist", name)
+	}
+
+	_, err := s.DoBatch(&sheets.Request{
+		DeleteNamedRange: &sheets.DeleteNamedRangeRequest{NamedRangeId: named.NamedRangeId},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete named range %s", name)
+	}
+
+	return nil
+}
+
+// ResolveRange returns the sheet and cells r refers to. Ranges that aren't
+// named are returned as is.
+func (s *Spreadsheet) ResolveRange(r SheetRange) (SheetRange, error) {
+	if r.Name == "" {
+		return r, nil
+	}
+
+	if err := s.refreshIfStale(); err != nil {
+		return SheetRange{}, err
+	}
+
+	named := s.GetNamedRange(r.Name)
+	if named == nil {
+		return SheetRange{}, errors.Errorf("named range %s does not exist", r.Name)
+	}
+
+	return named.Range, nil
+}
+
+// gridRange returns the grid range r refers to, resolving named ranges.
+func (s *Spreadsheet) gridRange(r SheetRange) (*sheets.GridRange, error) {
+	if r.Name != "" {
+		if err := s.refreshIfStale(); err != nil {
+			return nil, err
+		}
+
+		named := s.namedRange(r.Name)
+		if named == nil {
+			return nil, errors.Errorf("named range %s does not exist", r.Name)
+		}
+
+		return named.Range, nil
+	}
+
+	sheet := s.GetSheet(r.SheetName)
+	if sheet == nil {
+		return nil, errors.Errorf("sheet %s does not exist", r.SheetName)
+	}
+
+	return r.Range.GridRange(sheet.Properties.SheetId), nil
+}
+
+func (s *Spreadsheet) namedRange(name string) *sheets.NamedRange {
+	for _, named := range s.NamedRanges {
+		if strings.EqualFold(named.Name, name) {
+			return named
+		}
+	}
+
+	return nil
+}
+
+func (s *Spreadsheet) namedRangeFromAPI(named *sheets.NamedRange) *NamedRange {
+	if named == nil || named.Range == nil {
+		return nil
+	}
+
+	sheet := s.sheetByID(named.Range.SheetId)
+	if sheet == nil {
+		return nil
+	}
+
+	return &NamedRange{
+		ID:   named.NamedRangeId,
+		Name: named.Name,
+		Range: SheetRange{
+			SheetName: sheet.Properties.Title,
+			Range:     cellRangeFromGrid(named.Range, sheet.Properties.GridProperties),
+		},
+	}
+}
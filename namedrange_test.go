@@ -0,0 +1,112 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func namedTestSpreadsheet(client *Client) *Spreadsheet {
+	ss := testSpreadsheet(client)
+	ss.NamedRanges = []*sheets.NamedRange{
+		{NamedRangeId: "n1", Name: "Totals", Range: &sheets.GridRange{
+			SheetId: 7, StartRowIndex: 9, EndRowIndex: 10, StartColumnIndex: 1, EndColumnIndex: 3,
+		}},
+	}
+
+	return ss
+}
+
+func TestResolveRange(t *testing.T) {
+	ss := namedTestSpreadsheet(nil)
+
+	resolved, err := ss.ResolveRange(ByName("totals"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolved.String() != "Data!B10:C10" {
+		t.Errorf("Wanted Data!B10:C10, but got %s", resolved.String())
+	}
+
+	plain := SheetRange{SheetName: "Sheet1", Range: CellRange{CellPos{0, 0}, CellPos{1, 1}}}
+	if resolved, _ := ss.ResolveRange(plain); resolved != plain {
+		t.Errorf("Wanted %v, but got %v", plain, resolved)
+	}
+
+	if _, err := ss.ResolveRange(ByName("missing")); err == nil {
+		t.Error("Expected an error for an unknown named range")
+	}
+
+	if got := ByName("Totals").String(); got != "Totals" {
+		t.Errorf("Wanted Totals, but got %s", got)
+	}
+}
+
+func TestBatchNamedRanges(t *testing.T) {
+	ss := namedTestSpreadsheet(nil)
+	header := SheetRange{SheetName: "Sheet1", Range: CellRange{CellPos{0, 0}, CellPos{0, 3}}}
+
+	b := ss.NewBatch().
+		AddNamedRange("Header", header).
+		Style(ByName("header"), NewStyle().Bold(true)).
+		Style(ByName("Totals"), NewStyle().Italic(true))
+	if b.err != nil {
+		t.Fatalf("Unexpected error: %v", b.err)
+	}
+
+	if got := b.ops[1][0].RepeatCell.Range; got.SheetId != 0 || got.EndColumnIndex != 4 {
+		t.Errorf("Wanted the range added in the batch, but got %+v", got)
+	}
+	if got := b.ops[2][0].RepeatCell.Range; got.SheetId != 7 || got.StartRowIndex != 9 {
+		t.Errorf("Wanted the spreadsheet's named range, but got %+v", got)
+	}
+
+	b = ss.NewBatch().Style(ByName("missing"), NewStyle().Bold(true))
+	if b.err == nil {
+		t.Error("Expected an error for an unknown named range")
+	}
+}
+
+func TestApplyNamedRangesLocally(t *testing.T) {
+	ss := namedTestSpreadsheet(nil)
+
+	ss.applyLocally([]*sheets.Request{
+		{AddNamedRange: &sheets.AddNamedRangeRequest{}},
+		{DeleteNamedRange: &sheets.DeleteNamedRangeRequest{NamedRangeId: "n1"}},
+	}, []*sheets.Response{
+		{AddNamedRange: &sheets.AddNamedRangeResponse{NamedRange: &sheets.NamedRange{
+			NamedRangeId: "n2", Name: "Header", Range: &sheets.GridRange{SheetId: 0, EndRowIndex: 1},
+		}}},
+		{},
+	})
+
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+
+	named := ss.ListNamedRanges()
+	if len(named) != 1 || named[0].ID != "n2" || named[0].Range.SheetName != "Sheet1" {
+		t.Errorf("Wanted only the Header range, but got %+v", named)
+	}
+}
+
+func TestGetNamedRangeValues(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/spreadsheets/ssid/values/Totals" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+
+		json.NewEncoder(w).Encode(sheets.ValueRange{Values: [][]interface{}{{"Total", 42}}})
+	}))
+	ss := namedTestSpreadsheet(client)
+
+	values, err := ss.GetRange(ByName("Totals"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(values) != 1 || values[0][0] != "Total" || values[0][1] != "42" {
+		t.Errorf("Wanted [[Total 42]], but got %v", values)
+	}
+}
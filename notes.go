@@ -0,0 +1,166 @@
+package sheets
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Cell is a cell's value along with its note and hyperlink.
+type Cell struct {
+	Pos CellPos
+	// Value is the value as displayed
+	Value string
+	// Formula is set for cells holding a formula
+	Formula   string
+	Note      string
+	Hyperlink string
+}
+
+// GetCells reads the cells of r, by row. Trailing empty rows and cells are
+// omitted.
+func (s *Sheet) GetCells(r CellRange) ([][]Cell, error) {
+	sheetRange := SheetRange{SheetName: s.Title(), Range: r}.quotedA1()
+
+	var resp *sheets.Spreadsheet
+	err := googleRetry(func() error {
+		var rerr error
+		resp, rerr = s.Client.Sheets.Spreadsheets.Get(s.Spreadsheet.Id()).
+			Ranges(sheetRange).
+			Fields("sheets(data(startRow,startColumn,rowData(values(formattedValue,userEnteredValue,note,hyperlink))))").
+			Do(s.Client.options...)
+
+		return rerr
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read cells %s", sheetRange)
+	}
+
+	if len(resp.Sheets) == 0 || len(resp.Sheets[0].Data) == 0 {
+		return nil, nil
+	}
+	grid := resp.Sheets[0].Data[0]
+
+	cells := make([][]Cell, len(grid.RowData))
+	for i, row := range grid.RowData {
+		cells[i] = make([]Cell, len(row.Values))
+		for j, data := range row.Values {
+			cell := Cell{
+				Pos:       CellPos{int(grid.StartRow) + i, int(grid.StartColumn) + j},
+				Value:     data.FormattedValue,
+				Note:      data.Note,
+				Hyperlink: data.Hyperlink,
+			}
+			if data.UserEnteredValue != nil && data.UserEnteredValue.FormulaValue != nil {
+				cell.Formula = *data.UserEnteredValue.FormulaValue
+			}
+			cells[i][j] = cell
+		}
+	}
+
+	return cells, nil
+}
+
+// SetNote sets the note shown when hovering the cell at pos. An empty note
+// removes it.
+func (s *Sheet) SetNote(pos CellPos, note string) error {
+	return s.SetNoteRange(CellRange{pos, pos}, note)
+}
+
+// SetNoteRange sets the same note on every cell of r.
+func (s *Sheet) SetNoteRange(r CellRange, note string) error {
+	_, err := s.doBatch(noteRequest(r.GridRange(s.Properties.SheetId), note))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't set note on %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// SetHyperlink makes the cell at pos a link to url showing text, with a
+// HYPERLINK formula. url can be a link to a sheet of the spreadsheet, see
+// Sheet.Link.
+func (s *Sheet) SetHyperlink(pos CellPos, url, text string) error {
+	return s.SetHyperlinkRange(CellRange{pos, pos}, url, text)
+}
+
+// SetHyperlinkRange makes every cell of r a link to url showing text.
+func (s *Sheet) SetHyperlinkRange(r CellRange, url, text string) error {
+	_, err := s.doBatch(hyperlinkRequest(r.GridRange(s.Properties.SheetId), url, text))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't set hyperlink on %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// Link returns a link to the sheet, for use in hyperlinks within the
+// spreadsheet.
+func (s *Sheet) Link() string {
+	return fmt.Sprintf("#gid=%d", s.Properties.SheetId)
+}
+
+// LinkRange returns a link selecting r in the sheet.
+func (s *Sheet) LinkRange(r CellRange) string {
+	sheetRange := SheetRange{SheetName: s.Title(), Range: r}.quotedA1()
+
+	return fmt.Sprintf("%s&range=%s", s.Link(), url.PathEscape(sheetRange))
+}
+
+// SetNote sets note on every cell of r.
+func (b *Batch) SetNote(r SheetRange, note string) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(noteRequest(gridRange, note))
+}
+
+// SetHyperlink makes every cell of r a link to url showing text.
+func (b *Batch) SetHyperlink(r SheetRange, url, text string) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(hyperlinkRequest(gridRange, url, text))
+}
+
+func noteRequest(gridRange *sheets.GridRange, note string) *sheets.Request {
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range:  gridRange,
+			Cell:   &sheets.CellData{Note: note},
+			Fields: "note",
+		},
+	}
+}
+
+func hyperlinkRequest(gridRange *sheets.GridRange, url, text string) *sheets.Request {
+	formula := hyperlinkFormula(url, text)
+
+	return &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range:  gridRange,
+			Cell:   &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &formula}},
+			Fields: "userEnteredValue",
+		},
+	}
+}
+
+func hyperlinkFormula(url, text string) string {
+	if text == "" {
+		return fmt.Sprintf("=HYPERLINK(%s)", formulaString(url))
+	}
+
+	return fmt.Sprintf("=HYPERLINK(%s, %s)", formulaString(url), formulaString(text))
+}
+
+// formulaString quotes s as a string literal in a formula.
+func formulaString(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
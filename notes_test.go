@@ -0,0 +1,89 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestHyperlinkFormula(t *testing.T) {
+	for _, tt := range []struct {
+		url, text string
+		expected  string
+	}{
+		{"https://example.com", "", `=HYPERLINK("https://example.com")`},
+		{"#gid=7", `The "Data" tab`, `=HYPERLINK("#gid=7", "The ""Data"" tab")`},
+	} {
+		if got := hyperlinkFormula(tt.url, tt.text); got != tt.expected {
+			t.Errorf("Wanted %s, but got %s", tt.expected, got)
+		}
+	}
+
+	sheet := testSpreadsheet(nil).GetSheet("Data")
+	sheet.Properties.Title = "My Data"
+	expected := "#gid=7&range=%27My%20Data%27%21A1:B10"
+	if got := sheet.LinkRange(CellRange{CellPos{0, 0}, CellPos{9, 1}}); got != expected {
+		t.Errorf("Wanted %s, but got %s", expected, got)
+	}
+}
+
+func TestGetCells(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The title looks like a cell reference
+		if got := r.URL.Query().Get("ranges"); got != "'A1'!B2:C3" {
+			t.Errorf("Wanted range 'A1'!B2:C3, but got %s", got)
+		}
+
+		formula := `=HYPERLINK("#gid=0", "Home")`
+		json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{{Data: []*sheets.GridData{{
+			StartRow:    1,
+			StartColumn: 1,
+			RowData: []*sheets.RowData{
+				{Values: []*sheets.CellData{
+					{FormattedValue: "42", Note: "Checked"},
+					{FormattedValue: "Home", Hyperlink: "#gid=0", UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &formula}},
+				}},
+			},
+		}}}}})
+	}))
+
+	sheet := testSpreadsheet(client).GetSheet("Data")
+	sheet.Properties.Title = "A1"
+	cells, err := sheet.GetCells(CellRange{CellPos{1, 1}, CellPos{2, 2}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(cells) != 1 || len(cells[0]) != 2 {
+		t.Fatalf("Wanted 1 row of 2 cells, but got %v", cells)
+	}
+
+	expected := []Cell{
+		{Pos: CellPos{1, 1}, Value: "42", Note: "Checked"},
+		{Pos: CellPos{1, 2}, Value: "Home", Formula: `=HYPERLINK("#gid=0", "Home")`, Hyperlink: "#gid=0"},
+	}
+	for i := range expected {
+		if cells[0][i] != expected[i] {
+			t.Errorf("Wanted %+v, but got %+v", expected[i], cells[0][i])
+		}
+	}
+}
+
+func TestBatchNotes(t *testing.T) {
+	r := SheetRange{SheetName: "Data", Range: CellRange{CellPos{0, 0}, CellPos{0, 0}}}
+
+	b := testSpreadsheet(nil).NewBatch().SetNote(r, "Source: CRM").SetNote(r, "")
+	if b.err != nil {
+		t.Fatalf("Unexpected error: %v", b.err)
+	}
+
+	for i, expected := range []string{`{"note":"Source: CRM"}`, `{}`} {
+		req := b.ops[i][0].RepeatCell
+		encoded, _ := json.Marshal(req.Cell)
+		if string(encoded) != expected || req.Fields != "note" {
+			t.Errorf("Wanted %s with fields note, but got %s with fields %s", expected, encoded, req.Fields)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package sheets
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Summarize is how a pivot table aggregates the values of a group.
+type Summarize string
+
+const (
+	SummarizeSum     Summarize = "SUM"
+	SummarizeAverage Summarize = "AVERAGE"
+	// SummarizeCount counts numeric values, SummarizeCountA every non empty
+	// value.
+	SummarizeCount  Summarize = "COUNT"
+	SummarizeCountA Summarize = "COUNTA"
+	SummarizeMin    Summarize = "MIN"
+	SummarizeMax    Summarize = "MAX"
+)
+
+// PivotTable summarizes the rows of Source, whose first row is a header.
+// Columns are referenced by their offset in Source, 0 being its first column.
+//
+//	err := summary.AddPivotTable(CellPos{0, 0}, PivotTable{
+//		Source: SheetRange{SheetName: "Raw", Range: CellRange{CellPos{0, 0}, CellPos{999, 5}}},
+//		Rows:   []PivotGroup{{Column: 2}},
+//		Values: []PivotValue{{Column: 5, Summarize: SummarizeSum}},
+//	})
+type PivotTable struct {
+	Source SheetRange
+
+	Rows    []PivotGroup
+	Columns []PivotGroup
+	Values  []PivotValue
+
+	// Filters only keeps the source rows whose value in a column is one of
+	// the listed values, keyed by column offset.
+	Filters map[int][]string
+	// ValuesInRows lists values below each other instead of side by side
+	ValuesInRows bool
+}
+
+// PivotGroup groups rows or columns of a pivot table by the distinct values
+// of a source column.
+type PivotGroup struct {
+	Column     int
+	Label      string
+	Descending bool
+	HideTotals bool
+}
+
+// PivotValue aggregates a source column for each group.
+type PivotValue struct {
+	Column    int
+	Summarize Summarize
+	// Name labels the value, defaulting to e.g. "SUM of Amount"
+	Name string
+}
+
+// AddPivotTable writes p with its top left corner at pos, replacing any pivot
+// table there. The table expands over the cells right and below pos.
+func (s *Sheet) AddPivotTable(pos CellPos, p PivotTable) error {
+	source, err := s.Spreadsheet.gridRange(p.Source)
+	if err != nil {
+		return err
+	}
+
+	req, err := pivotTableRequest(s.Properties.SheetId, pos, source, p)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.doBatch(req)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't add pivot table to %s!%s", s.Title(), pos.A1Notation())
+	}
+
+	return nil
+}
+
+// DeletePivotTable deletes the pivot table whose top left corner is at pos.
+func (s *Sheet) DeletePivotTable(pos CellPos) error {
+	_, err := s.doBatch(updateCellsRequest(s.Properties.SheetId, int64(pos.Row), int64(pos.Col),
+		[]*sheets.RowData{{Values: []*sheets.CellData{{}}}}, "pivotTable"))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete pivot table at %s!%s", s.Title(), pos.A1Notation())
+	}
+
+	return nil
+}
+
+// AddPivotTable writes p at pos in the sheet titled title. p.Source can be a
+// sheet added earlier in the batch.
+func (b *Batch) AddPivotTable(title string, pos CellPos, p PivotTable) *Batch {
+	id, ok := b.sheetID(title)
+	if !ok {
+		return b.unknownSheet(title)
+	}
+
+	source, err := b.gridRange(p.Source)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	req, err := pivotTableRequest(id, pos, source, p)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(req)
+}
+
+func pivotTableRequest(sheetID int64, pos CellPos, source *sheets.GridRange, p PivotTable) (*sheets.Request, error) {
+	if len(p.Rows) == 0 && len(p.Columns) == 0 && len(p.Values) == 0 {
+		return nil, errors.New("pivot table has no rows, columns or values")
+	}
+
+	pivot := &sheets.PivotTable{
+		Source:  source,
+		Rows:    pivotGroups(p.Rows),
+		Columns: pivotGroups(p.Columns),
+	}
+
+	for _, v := range p.Values {
+		if v.Summarize == "" {
+			return nil, errors.Errorf("pivot value on column %d has no summarize function", v.Column)
+		}
+
+		pivot.Values = append(pivot.Values, &sheets.PivotValue{
+			SourceColumnOffset: int64(v.Column),
+			SummarizeFunction:  string(v.Summarize),
+			Name:               v.Name,
+			ForceSendFields:    []string{"SourceColumnOffset"},
+		})
+	}
+
+	if len(p.Filters) > 0 {
+		pivot.Criteria = map[string]sheets.PivotFilterCriteria{}
+		for col, visible := range p.Filters {
+			pivot.Criteria[strconv.Itoa(col)] = sheets.PivotFilterCriteria{VisibleValues: visible}
+		}
+	}
+
+	if p.ValuesInRows {
+		pivot.ValueLayout = "VERTICAL"
+	}
+
+	return updateCellsRequest(sheetID, int64(pos.Row), int64(pos.Col),
+		[]*sheets.RowData{{Values: []*sheets.CellData{{PivotTable: pivot}}}}, "pivotTable"), nil
+}
+
+func pivotGroups(groups []PivotGroup) []*sheets.PivotGroup {
+	var apiGroups []*sheets.PivotGroup
+	for _, g := range groups {
+		order := "ASCENDING"
+		if g.Descending {
+			order = "DESCENDING"
+		}
+
+		apiGroups = append(apiGroups, &sheets.PivotGroup{
+			SourceColumnOffset: int64(g.Column),
+			Label:              g.Label,
+			SortOrder:          order,
+			ShowTotals:         !g.HideTotals,
+			ForceSendFields:    []string{"SourceColumnOffset"},
+		})
+	}
+
+	return apiGroups
+}
@@ -0,0 +1,57 @@
+package sheets
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPivotTableRequest(t *testing.T) {
+	ss := testSpreadsheet(nil)
+	p := PivotTable{
+		Source:  SheetRange{SheetName: "Data", Range: CellRange{CellPos{0, 0}, CellPos{99, 3}}},
+		Rows:    []PivotGroup{{Column: 0}},
+		Columns: []PivotGroup{{Column: 1, Descending: true, HideTotals: true}},
+		Values:  []PivotValue{{Column: 3, Summarize: SummarizeSum, Name: "Revenue"}},
+		Filters: map[int][]string{2: {"EU"}},
+	}
+
+	b := ss.NewBatch().AddSheet("Summary").AddPivotTable("Summary", CellPos{1, 0}, p)
+	if b.err != nil {
+		t.Fatalf("Unexpected error: %v", b.err)
+	}
+
+	encoded, err := json.Marshal(b.ops[1][0].UpdateCells)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	summaryID := b.ops[0][0].AddSheet.Properties.SheetId
+	var got map[string]interface{}
+	json.Unmarshal(encoded, &got)
+	start := got["start"].(map[string]interface{})
+	if int64(start["sheetId"].(float64)) != summaryID || start["rowIndex"].(float64) != 1 || start["columnIndex"].(float64) != 0 {
+		t.Errorf("Unexpected start %v", start)
+	}
+	if got["fields"] != "pivotTable" {
+		t.Errorf("Wanted fields pivotTable, but got %v", got["fields"])
+	}
+
+	pivot, _ := json.Marshal(b.ops[1][0].UpdateCells.Rows[0].Values[0].PivotTable)
+	expected := `{"columns":[{"sortOrder":"DESCENDING","sourceColumnOffset":1}],"criteria":{"2":{"visibleValues":["EU"]}},` +
+		`"rows":[{"showTotals":true,"sortOrder":"ASCENDING","sourceColumnOffset":0}],` +
+		`"source":{"endColumnIndex":4,"endRowIndex":100,"sheetId":7},` +
+		`"values":[{"name":"Revenue","sourceColumnOffset":3,"summarizeFunction":"SUM"}]}`
+	if string(pivot) != expected {
+		t.Errorf("Wanted %s, but got %s", expected, pivot)
+	}
+
+	for _, invalid := range []PivotTable{
+		{Source: p.Source},
+		{Source: p.Source, Values: []PivotValue{{Column: 1}}},
+		{Source: SheetRange{SheetName: "Missing"}, Rows: p.Rows},
+	} {
+		if b := ss.NewBatch().AddPivotTable("Sheet1", CellPos{}, invalid); b.err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
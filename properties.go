@@ -0,0 +1,89 @@
+package sheets
+
+import (
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// RGB returns the color with the given red, green and blue components.
+func RGB(r, g, b uint8) *sheets.Color {
+	return &sheets.Color{
+		Red:   float64(r) / 255,
+		Green: float64(g) / 255,
+		Blue:  float64(b) / 255,
+		Alpha: 1,
+	}
+}
+
+func (s *Sheet) Rename(title string) error {
+	return s.updateProperties(&sheets.SheetProperties{Title: title}, "title")
+}
+
+// MoveTo moves the sheet so that it ends up at index among the spreadsheet's
+// sheets.
+func (s *Sheet) MoveTo(index int) error {
+	// The API expects the index before the sheet is removed from its current
+	// position
+	if int64(index) > s.Properties.Index {
+		index++
+	}
+
+	return s.updateProperties(&sheets.SheetProperties{Index: int64(index)}, "index")
+}
+
+func (s *Sheet) Hide() error {
+	return s.updateProperties(&sheets.SheetProperties{Hidden: true}, "hidden")
+}
+
+func (s *Sheet) Unhide() error {
+	return s.updateProperties(&sheets.SheetProperties{Hidden: false}, "hidden")
+}
+
+// SetTabColor sets the color of the sheet's tab, or resets it if color is
+// nil.
+func (s *Sheet) SetTabColor(color *sheets.Color) error {
+	return s.updateProperties(&sheets.SheetProperties{TabColor: color}, "tabColor")
+}
+
+// FreezeRows freezes the first n rows of the sheet, e.g. a header row. Zero
+// unfreezes them.
+func (s *Sheet) FreezeRows(n int) error {
+	return s.updateProperties(&sheets.SheetProperties{
+		GridProperties: &sheets.GridProperties{FrozenRowCount: int64(n)},
+	}, "gridProperties.frozenRowCount")
+}
+
+// FreezeColumns freezes the first n columns of the sheet. Zero unfreezes them.
+func (s *Sheet) FreezeColumns(n int) error {
+	return s.updateProperties(&sheets.SheetProperties{
+		GridProperties: &sheets.GridProperties{FrozenColumnCount: int64(n)},
+	}, "gridProperties.frozenColumnCount")
+}
+
+func (s *Sheet) HideGridlines(hide bool) error {
+	return s.updateProperties(&sheets.SheetProperties{
+		GridProperties: &sheets.GridProperties{HideGridlines: hide},
+	}, "gridProperties.hideGridlines")
+}
+
+func (s *Sheet) SetRightToLeft(rightToLeft bool) error {
+	return s.updateProperties(&sheets.SheetProperties{RightToLeft: rightToLeft}, "rightToLeft")
+}
+
+// updateProperties updates the fields of the sheet's properties listed in
+// fields with the values from props.
+func (s *Sheet) updateProperties(props *sheets.SheetProperties, fields string) error {
+	props.SheetId = s.Properties.SheetId
+
+	_, err := s.doBatch(&sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: props,
+			Fields:     fields,
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update %s of sheet %s", fields, s.Title())
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestSheetPropertiesLightweight(t *testing.T) {
+	var requests []*sheets.BatchUpdateSpreadsheetRequest
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, &req)
+
+		json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{
+			Replies: []*sheets.Response{{}},
+		})
+	}))
+	client.LightweightBatches = true
+
+	ss := testSpreadsheet(client)
+	sheet := ss.GetSheet("Sheet1")
+
+	if err := sheet.Rename("Summary"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := sheet.MoveTo(1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := sheet.FreezeRows(2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, req := range requests {
+		if req.IncludeSpreadsheetInResponse {
+			t.Error("Expected lightweight requests")
+		}
+	}
+	if got := requests[1].Requests[0].UpdateSheetProperties.Properties.Index; got != 2 {
+		t.Errorf("Wanted index 2 before the move, but got %d", got)
+	}
+
+	if ss.Stale() {
+		t.Error("Expected the cache to be up to date")
+	}
+	if ss.GetSheet("Summary") == nil {
+		t.Error("Expected the renamed sheet to be cached")
+	}
+	if sheet.Properties.Index != 1 || ss.GetSheet("Data").Properties.Index != 0 {
+		t.Errorf("Unexpected sheet order %v", sheet.Properties.Index)
+	}
+	if sheet.Properties.GridProperties.FrozenRowCount != 2 {
+		t.Errorf("Wanted 2 frozen rows, but got %d", sheet.Properties.GridProperties.FrozenRowCount)
+	}
+}
@@ -0,0 +1,257 @@
+package sheets
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Editors are the users allowed to edit a protected range, in addition to the
+// spreadsheet's owner.
+type Editors struct {
+	Users  []string
+	Groups []string
+	// DomainUsersCanEdit lets anyone in the spreadsheet's domain edit
+	DomainUsersCanEdit bool
+}
+
+// Protection describes how a range is protected.
+type Protection struct {
+	Description string
+	// WarningOnly lets anyone edit the range after showing a warning. Editors
+	// must be empty.
+	WarningOnly bool
+	Editors     Editors
+	// Unprotected are ranges that anyone can edit in a protected sheet
+	Unprotected []CellRange
+}
+
+// ProtectedRange is a protected range, or a whole protected sheet when Range
+// is nil.
+type ProtectedRange struct {
+	ID      int64
+	SheetID int64
+	Range   *CellRange
+
+	Protection
+}
+
+// ProtectRange protects r and returns the new protected range.
+func (s *Spreadsheet) ProtectRange(r SheetRange, p Protection) (*ProtectedRange, error) {
+	gridRange, err := s.gridRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.addProtectedRange(gridRange, p)
+}
+
+// Protect protects the whole sheet, except p.Unprotected ranges, and returns
+// the new protected range.
+func (s *Sheet) Protect(p Protection) (*ProtectedRange, error) {
+	pr, err := s.Spreadsheet.addProtectedRange(&sheets.GridRange{SheetId: s.Properties.SheetId}, p)
+	if err != nil {
+		return nil, err
+	}
+	s.refresh()
+
+	return pr, nil
+}
+
+// ListProtectedRanges returns the protected ranges of every sheet.
+func (s *Spreadsheet) ListProtectedRanges() []*ProtectedRange {
+	var all []*ProtectedRange
+	for _, sheet := range s.AllSheets() {
+		all = append(all, sheet.ListProtectedRanges()...)
+	}
+
+	return all
+}
+
+// ListProtectedRanges returns the sheet's protected ranges.
+func (s *Sheet) ListProtectedRanges() []*ProtectedRange {
+	s.refresh()
+
+	ranges := make([]*ProtectedRange, len(s.Sheet.ProtectedRanges))
+	for i, pr := range s.Sheet.ProtectedRanges {
+		ranges[i] = protectedRangeFromAPI(pr, s.Properties.GridProperties)
+	}
+
+	return ranges
+}
+
+// UpdateProtectedRangeEditors replaces the editors of the protected range
+// identified by id.
+func (s *Spreadsheet) UpdateProtectedRangeEditors(id int64, editors Editors) error {
+	_, err := s.DoBatch(&sheets.Request{
+		UpdateProtectedRange: &sheets.UpdateProtectedRangeRequest{
+			ProtectedRange: &sheets.ProtectedRange{
+				ProtectedRangeId: id,
+				Editors:          editors.apiEditors(),
+			},
+			Fields: "editors",
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update editors of protected range %d", id)
+	}
+
+	return nil
+}
+
+func (s *Spreadsheet) DeleteProtectedRange(id int64) error {
+	_, err := s.DoBatch(&sheets.Request{
+		DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{
+			ProtectedRangeId: id,
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete protected range %d", id)
+	}
+
+	return nil
+}
+
+func (s *Spreadsheet) addProtectedRange(gridRange *sheets.GridRange, p Protection) (*ProtectedRange, error) {
+	apiRange, err := p.apiProtectedRange(gridRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ranges that exist before the call can't be the new one
+	if err := s.refreshIfStale(); err != nil {
+		return nil, err
+	}
+	existing := map[int64]bool{}
+	if sheet := s.sheetByID(gridRange.SheetId); sheet != nil {
+		for _, pr := range sheet.ProtectedRanges {
+			existing[pr.ProtectedRangeId] = true
+		}
+	}
+
+	resp, err := s.DoBatch(&sheets.Request{
+		AddProtectedRange: &sheets.AddProtectedRangeRequest{ProtectedRange: apiRange},
+	})
+	if err != nil {
+		if !isFakeProtectedRangeError(err) {
+			return nil, errors.Wrap(err, "couldn't add protected range to sheet")
+		}
+
+		// The range was added by an attempt whose reply was lost, look it up
+		// among the new ranges to find its ID
+		if err := s.Refresh(); err != nil {
+			return nil, errors.Wrap(err, "error refreshing spreadsheet after fake protected range error")
+		}
+
+		return s.findProtectedRange(apiRange, existing)
+	}
+
+	if len(resp.Replies) == 0 || resp.Replies[0].AddProtectedRange == nil ||
+		resp.Replies[0].AddProtectedRange.ProtectedRange == nil {
+		return nil, errors.New("protected range missing from response")
+	}
+
+	sheet := s.sheetByID(gridRange.SheetId)
+	var grid *sheets.GridProperties
+	if sheet != nil {
+		grid = sheet.Properties.GridProperties
+	}
+
+	return protectedRangeFromAPI(resp.Replies[0].AddProtectedRange.ProtectedRange, grid), nil
+}
+
+// findProtectedRange returns the protected range matching want, other than
+// the existing ones.
+func (s *Spreadsheet) findProtectedRange(want *sheets.ProtectedRange, existing map[int64]bool) (*ProtectedRange, error) {
+	sheet := s.sheetByID(want.Range.SheetId)
+	if sheet == nil {
+		return nil, errors.New("protected sheet does not exist")
+	}
+
+	var found *sheets.ProtectedRange
+	for _, pr := range sheet.ProtectedRanges {
+		if existing[pr.ProtectedRangeId] {
+			continue
+		}
+		if pr.Description == want.Description && reflect.DeepEqual(pr.Range, want.Range) {
+			found = pr
+		}
+	}
+	if found == nil {
+		return nil, errors.New("protected range does not exist")
+	}
+
+	return protectedRangeFromAPI(found, sheet.Properties.GridProperties), nil
+}
+
+func (p Protection) apiProtectedRange(gridRange *sheets.GridRange) (*sheets.ProtectedRange, error) {
+	pr := &sheets.ProtectedRange{
+		Range:       gridRange,
+		Description: p.Description,
+		WarningOnly: p.WarningOnly,
+	}
+
+	editors := p.Editors.apiEditors()
+	if p.WarningOnly {
+		if editors != nil {
+			return nil, errors.New("warning only protected ranges can't have editors")
+		}
+	} else if editors != nil {
+		pr.Editors = editors
+	}
+
+	for _, r := range p.Unprotected {
+		pr.UnprotectedRanges = append(pr.UnprotectedRanges, r.GridRange(gridRange.SheetId))
+	}
+
+	return pr, nil
+}
+
+func (e Editors) apiEditors() *sheets.Editors {
+	if len(e.Users) == 0 && len(e.Groups) == 0 && !e.DomainUsersCanEdit {
+		return nil
+	}
+
+	return &sheets.Editors{
+		Users:              e.Users,
+		Groups:             e.Groups,
+		DomainUsersCanEdit: e.DomainUsersCanEdit,
+	}
+}
+
+func protectedRangeFromAPI(pr *sheets.ProtectedRange, grid *sheets.GridProperties) *ProtectedRange {
+	p := &ProtectedRange{
+		ID: pr.ProtectedRangeId,
+		Protection: Protection{
+			Description: pr.Description,
+			WarningOnly: pr.WarningOnly,
+		},
+	}
+
+	if pr.Range != nil {
+		p.SheetID = pr.Range.SheetId
+		if !isWholeSheet(pr.Range) {
+			r := cellRangeFromGrid(pr.Range, grid)
+			p.Range = &r
+		}
+	}
+
+	if pr.Editors != nil {
+		p.Editors = Editors{
+			Users:              pr.Editors.Users,
+			Groups:             pr.Editors.Groups,
+			DomainUsersCanEdit: pr.Editors.DomainUsersCanEdit,
+		}
+	}
+
+	for _, r := range pr.UnprotectedRanges {
+		p.Unprotected = append(p.Unprotected, cellRangeFromGrid(r, grid))
+	}
+
+	return p
+}
+
+func isWholeSheet(g *sheets.GridRange) bool {
+	return g.StartRowIndex == 0 && g.EndRowIndex == 0 && g.StartColumnIndex == 0 && g.EndColumnIndex == 0
+}
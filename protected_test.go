@@ -0,0 +1,93 @@
+package sheets
+
+import (
+	"encoding/json"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+var protectionTests = []struct {
+	protection Protection
+	expected   string
+}{
+	{
+		Protection{Description: "Totals", Editors: Editors{Users: []string{"a@example.com"}}},
+		`{"description":"Totals","editors":{"users":["a@example.com"]},"range":{"endColumnIndex":2,"endRowIndex":1,"sheetId":7}}`,
+	},
+	{
+		Protection{WarningOnly: true},
+		`{"range":{"endColumnIndex":2,"endRowIndex":1,"sheetId":7},"warningOnly":true}`,
+	},
+	{
+		Protection{Unprotected: []CellRange{{CellPos{0, 1}, CellPos{0, 1}}}},
+		`{"range":{"endColumnIndex":2,"endRowIndex":1,"sheetId":7},"unprotectedRanges":[{"endColumnIndex":2,"endRowIndex":1,"sheetId":7,"startColumnIndex":1}]}`,
+	},
+}
+
+func TestProtectionAPIRange(t *testing.T) {
+	gridRange := CellRange{CellPos{0, 0}, CellPos{0, 1}}.GridRange(7)
+
+	for _, tt := range protectionTests {
+		pr, err := tt.protection.apiProtectedRange(gridRange)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		encoded, err := json.Marshal(pr)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if string(encoded) != tt.expected {
+			t.Errorf("Wanted %s, but got %s", tt.expected, encoded)
+		}
+	}
+
+	invalid := Protection{WarningOnly: true, Editors: Editors{DomainUsersCanEdit: true}}
+	if _, err := invalid.apiProtectedRange(gridRange); err == nil {
+		t.Error("Expected an error for a warning only range with editors")
+	}
+}
+
+func TestProtectedRangeFromAPI(t *testing.T) {
+	grid := &sheets.GridProperties{RowCount: 100, ColumnCount: 10}
+
+	whole := protectedRangeFromAPI(&sheets.ProtectedRange{
+		ProtectedRangeId: 12,
+		Range:            &sheets.GridRange{SheetId: 7},
+		Editors:          &sheets.Editors{Groups: []string{"team@example.com"}},
+	}, grid)
+	if whole.ID != 12 || whole.SheetID != 7 || whole.Range != nil {
+		t.Errorf("Wanted whole sheet range 12 on sheet 7, but got %+v", whole)
+	}
+	if len(whole.Editors.Groups) != 1 {
+		t.Errorf("Wanted 1 editor group, but got %v", whole.Editors.Groups)
+	}
+
+	partial := protectedRangeFromAPI(&sheets.ProtectedRange{
+		Range: &sheets.GridRange{SheetId: 7, StartRowIndex: 1, EndRowIndex: 3, EndColumnIndex: 2},
+	}, grid)
+	expected := CellRange{CellPos{1, 0}, CellPos{2, 1}}
+	if partial.Range == nil || *partial.Range != expected {
+		t.Errorf("Wanted %v, but got %v", expected, partial.Range)
+	}
+}
+
+func TestFindProtectedRangeSkipsExisting(t *testing.T) {
+	gridRange := CellRange{CellPos{0, 0}, CellPos{0, 1}}.GridRange(7)
+	ss := testSpreadsheet(nil)
+	ss.sheetByID(7).ProtectedRanges = []*sheets.ProtectedRange{
+		{ProtectedRangeId: 1, Description: "header", Range: gridRange},
+		{ProtectedRangeId: 2, Description: "header", Range: gridRange},
+	}
+
+	found, err := ss.findProtectedRange(&sheets.ProtectedRange{Description: "header", Range: gridRange},
+		map[int64]bool{1: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if found.ID != 2 {
+		t.Errorf("Wanted protected range 2, but got %d", found.ID)
+	}
+}
@@ -0,0 +1,197 @@
+package sheets
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+const defaultRowChunkSize = 1000
+
+// RowIterator pages through a sheet's values in chunks of rows, so that very
+// large sheets can be processed without loading the whole grid in memory.
+//
+//	rows := sheet.Rows(0, 5000)
+//	for rows.Next() {
+//		process(rows.RowIndex(), rows.Row())
+//	}
+//	if err := rows.Err(); err != nil {
+//		// Resume later with sheet.Rows(lastIndex+1, 5000)
+//	}
+type RowIterator struct {
+	sheet     *Sheet
+	chunkSize int
+
+	// next is the index of the first row of the next chunk to fetch
+	next int
+	done bool
+
+	// rowCount is the sheet's row count as last read from the API, or -1
+	// before the first read
+	rowCount int
+
+	chunk      [][]interface{}
+	chunkStart int
+	pos        int
+
+	row      []string
+	rowIndex int
+	err      error
+}
+
+// Rows returns an iterator over the rows of the sheet, starting at startRow
+// (0-based) and fetching chunkSize rows per request. A chunkSize of 0 or less
+// uses a default of 1000 rows.
+//
+// Rows are read with ranged value reads and do not require the sheet to have
+// been fetched with GetSpreadsheetWithData. Blank rows are skipped.
+func (s *Sheet) Rows(startRow, chunkSize int) *RowIterator {
+	if chunkSize <= 0 {
+		chunkSize = defaultRowChunkSize
+	}
+	if startRow < 0 {
+		startRow = 0
+	}
+
+	return &RowIterator{
+		sheet:     s,
+		chunkSize: chunkSize,
+		next:      startRow,
+		rowCount:  -1,
+		rowIndex:  -1,
+	}
+}
+
+// Next advances the iterator to the next non-blank row. It returns false when
+// there are no more rows or an error occurred, see Err.
+func (it *RowIterator) Next() bool {
+	for {
+		for it.pos < len(it.chunk) {
+			values := it.chunk[it.pos]
+			it.pos++
+
+			if len(values) == 0 {
+				continue
+			}
+
+			it.rowIndex = it.chunkStart + it.pos - 1
+			it.row = ifaceToStr(values)
+			return true
+		}
+
+		if it.done || it.err != nil {
+			it.row = nil
+			return false
+		}
+
+		it.fetch()
+	}
+}
+
+// Row returns the current row's formatted values.
+func (it *RowIterator) Row() []string {
+	return it.row
+}
+
+// RowIndex returns the 0-based index of the current row in the sheet. Passing
+// RowIndex()+1 to Sheet.Rows resumes iteration after the current row.
+func (it *RowIterator) RowIndex() int {
+	return it.rowIndex
+}
+
+// Err returns the first error encountered while fetching rows.
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+func (it *RowIterator) fetch() {
+	s := it.sheet
+
+	// The cached row count is out of date after writes made elsewhere, so it's
+	// read again before starting and before stopping, in case rows were added
+	// in the meantime.
+	if it.rowCount < 0 || it.next >= it.rowCount {
+		rowCount, err := s.currentRowCount()
+		if err != nil {
+			it.err = err
+			it.chunk = nil
+			return
+		}
+		if it.next >= rowCount {
+			it.done = true
+			it.chunk = nil
+			return
+		}
+		it.rowCount = rowCount
+	}
+
+	start := it.next
+	end := start + it.chunkSize
+	if end > it.rowCount {
+		end = it.rowCount
+	}
+	sheetRange := fmt.Sprintf("%s!%d:%d", quoteSheetName(s.Title()), start+1, end)
+
+	var resp *sheets.ValueRange
+	err := googleRetry(func() error {
+		var rerr error
+		resp, rerr = s.Client.Sheets.Spreadsheets.Values.Get(s.Spreadsheet.Id(), sheetRange).
+			MajorDimension("ROWS").Do(s.Client.options...)
+
+		return rerr
+	})
+	if err != nil {
+		it.err = errors.Wrapf(err, "couldn't read rows %s", sheetRange)
+		it.chunk = nil
+		return
+	}
+
+	it.chunk = resp.Values
+	it.chunkStart = start
+	it.pos = 0
+	it.next = end
+}
+
+// currentRowCount reads the sheet's row count from the API, and updates the
+// cached grid properties with it.
+func (s *Sheet) currentRowCount() (int, error) {
+	var resp *sheets.Spreadsheet
+	err := googleRetry(func() error {
+		var rerr error
+		resp, rerr = s.Client.Sheets.Spreadsheets.Get(s.Spreadsheet.Id()).
+			Fields("sheets(properties(sheetId,gridProperties(rowCount)))").
+			Do(s.Client.options...)
+
+		return rerr
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't read row count of %s", s.Title())
+	}
+
+	for _, sheet := range resp.Sheets {
+		props := sheet.Properties
+		if props == nil || props.SheetId != s.Properties.SheetId || props.GridProperties == nil {
+			continue
+		}
+
+		if s.Properties.GridProperties != nil {
+			s.Properties.GridProperties.RowCount = props.GridProperties.RowCount
+		}
+		return int(props.GridProperties.RowCount), nil
+	}
+
+	return 0, errors.Errorf("sheet %s not found", s.Title())
+}
+
+func ifaceToStr(values []interface{}) []string {
+	arr := make([]string, len(values))
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		arr[i] = fmt.Sprint(v)
+	}
+	return arr
+}
@@ -0,0 +1,70 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestRowsPagesThroughSheet(t *testing.T) {
+	chunks := map[string][][]interface{}{
+		"'Data'!3:4": {{"a", "1"}, {}},
+		"'Data'!5:6": {{"b", 2}},
+		"'Data'!7:7": {},
+	}
+
+	var requested []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v4/spreadsheets/ssid" {
+			// The sheet grew since it was cached
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{{
+				Properties: &sheets.SheetProperties{SheetId: 3, GridProperties: &sheets.GridProperties{RowCount: 7}},
+			}}})
+			return
+		}
+
+		// Path is /v4/spreadsheets/{id}/values/{range}
+		rng := r.URL.Path[len("/v4/spreadsheets/ssid/values/"):]
+		requested = append(requested, rng)
+
+		json.NewEncoder(w).Encode(&sheets.ValueRange{Range: rng, Values: chunks[rng]})
+	}))
+
+	ss := &Spreadsheet{client, &sheets.Spreadsheet{SpreadsheetId: "ssid"}}
+	sheet := &Sheet{&sheets.Sheet{Properties: &sheets.SheetProperties{
+		SheetId:        3,
+		Title:          "Data",
+		GridProperties: &sheets.GridProperties{RowCount: 4},
+	}}, ss, client}
+
+	var (
+		gotRows    [][]string
+		gotIndexes []int
+	)
+	rows := sheet.Rows(2, 2)
+	for rows.Next() {
+		gotRows = append(gotRows, rows.Row())
+		gotIndexes = append(gotIndexes, rows.RowIndex())
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantRequested := []string{"'Data'!3:4", "'Data'!5:6", "'Data'!7:7"}
+	if !reflect.DeepEqual(requested, wantRequested) {
+		t.Errorf("Wanted requests %v, but got %v", wantRequested, requested)
+	}
+
+	wantRows := [][]string{{"a", "1"}, {"b", "2"}}
+	if !reflect.DeepEqual(gotRows, wantRows) {
+		t.Errorf("Wanted rows %v, but got %v", wantRows, gotRows)
+	}
+
+	wantIndexes := []int{2, 4}
+	if !reflect.DeepEqual(gotIndexes, wantIndexes) {
+		t.Errorf("Wanted indexes %v, but got %v", wantIndexes, gotIndexes)
+	}
+}
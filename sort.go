@@ -0,0 +1,168 @@
+package sheets
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Sort sorts the rows of r by specs, in order of precedence. Formulas and
+// formatting move with their rows. Every row of r is sorted: use
+// SortWithHeader when r starts with a header row.
+func (s *Sheet) Sort(r CellRange, specs ...SortSpec) error {
+	if err := checkNoHeaders(specs); err != nil {
+		return err
+	}
+
+	return s.sort(r, specs)
+}
+
+// SortWithHeader is like Sort, but keeps the first row of r in place as a
+// header row. Specs can reference columns by Header, in which case the header
+// row is read to find them.
+//
+//	err := sheet.SortWithHeader(r, SortSpec{Header: "Region"}, SortSpec{Header: "Total", Descending: true})
+func (s *Sheet) SortWithHeader(r CellRange, specs ...SortSpec) error {
+	resolved, err := s.resolveSortSpecs(r, specs)
+	if err != nil {
+		return err
+	}
+
+	r.Start.Row++
+	if r.Start.Row > r.End.Row {
+		return nil
+	}
+
+	return s.sort(r, resolved)
+}
+
+func (s *Sheet) sort(r CellRange, specs []SortSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	_, err := s.doBatch(sortRequest(r.GridRange(s.Properties.SheetId), specs))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't sort %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// Sort sorts the rows of r by specs. Columns must be referenced by index.
+func (b *Batch) Sort(r SheetRange, specs ...SortSpec) *Batch {
+	if err := checkNoHeaders(specs); err != nil {
+		return b.fail(err)
+	}
+
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(sortRequest(gridRange, specs))
+}
+
+// SortWithHeader is like Sort, but keeps the first row of r in place as a
+// header row. Columns must still be referenced by index.
+func (b *Batch) SortWithHeader(r SheetRange, specs ...SortSpec) *Batch {
+	for _, spec := range specs {
+		if spec.Header != "" {
+			return b.fail(errors.Errorf("batches can't sort by header %s, use a column index", spec.Header))
+		}
+	}
+
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	// Copied as named ranges share their grid range
+	data := *gridRange
+	data.StartRowIndex++
+	if data.EndRowIndex != 0 && data.StartRowIndex >= data.EndRowIndex {
+		return b
+	}
+
+	return b.Add(sortRequest(&data, specs))
+}
+
+// checkNoHeaders returns an error if a spec references a column by header,
+// which requires a header row.
+func checkNoHeaders(specs []SortSpec) error {
+	for _, spec := range specs {
+		if spec.Header != "" {
+			return errors.Errorf("sorting by header %s requires a header row, use SortWithHeader", spec.Header)
+		}
+	}
+
+	return nil
+}
+
+// resolveSortSpecs replaces header references by the index of their column,
+// reading the first row of r if needed.
+func (s *Sheet) resolveSortSpecs(r CellRange, specs []SortSpec) ([]SortSpec, error) {
+	byHeader := false
+	for _, spec := range specs {
+		if spec.Header != "" {
+			byHeader = true
+		}
+	}
+	if !byHeader {
+		return specs, nil
+	}
+
+	headerRange := SheetRange{
+		SheetName: s.Title(),
+		Range:     CellRange{r.Start, CellPos{r.Start.Row, r.End.Col}},
+	}
+	values, err := s.Spreadsheet.GetRange(headerRange)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read header row")
+	}
+
+	var header []string
+	if len(values) > 0 {
+		header = values[0]
+	}
+
+	resolved := make([]SortSpec, len(specs))
+	for i, spec := range specs {
+		resolved[i] = spec
+		if spec.Header == "" {
+			continue
+		}
+
+		col := headerIndex(header, spec.Header)
+		if col < 0 {
+			return nil, errors.Errorf("column %s not found in %s", spec.Header, headerRange.String())
+		}
+		resolved[i].Column = r.Start.Col + col
+		resolved[i].Header = ""
+	}
+
+	return resolved, nil
+}
+
+// headerIndex returns the position of name in header, ignoring case and
+// surrounding spaces, or -1.
+func headerIndex(header []string, name string) int {
+	name = strings.TrimSpace(name)
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func sortRequest(gridRange *sheets.GridRange, specs []SortSpec) *sheets.Request {
+	return &sheets.Request{
+		SortRange: &sheets.SortRangeRequest{
+			Range:     gridRange,
+			SortSpecs: apiSortSpecs(specs),
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func TestSortByHeader(t *testing.T) {
+	var got *sheets.SortRangeRequest
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/values/") {
+			if !strings.HasSuffix(r.URL.Path, "/values/Data!B2:D2") {
+				t.Errorf("Unexpected header read %s", r.URL.Path)
+			}
+			json.NewEncoder(w).Encode(sheets.ValueRange{Values: [][]interface{}{{"Region", " Total ", "Notes"}}})
+			return
+		}
+
+		var req sheets.BatchUpdateSpreadsheetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = req.Requests[0].SortRange
+
+		json.NewEncoder(w).Encode(&sheets.BatchUpdateSpreadsheetResponse{Replies: []*sheets.Response{{}}})
+	}))
+	client.LightweightBatches = true
+
+	sheet := testSpreadsheet(client).GetSheet("Data")
+	r := CellRange{CellPos{1, 1}, CellPos{20, 3}}
+	err := sheet.SortWithHeader(r, SortSpec{Header: "region"}, SortSpec{Header: "Total", Descending: true}, SortSpec{Column: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got == nil {
+		t.Fatal("Expected a sort request")
+	}
+	if got.Range.StartRowIndex != 2 || got.Range.EndRowIndex != 21 {
+		t.Errorf("Wanted rows 2 to 21 sorted, but got %+v", got.Range)
+	}
+
+	expected := []SortSpec{{Column: 1}, {Column: 2, Descending: true}, {Column: 3}}
+	for i, spec := range got.SortSpecs {
+		if int(spec.DimensionIndex) != expected[i].Column || (spec.SortOrder == "DESCENDING") != expected[i].Descending {
+			t.Errorf("Wanted %v, but got %+v", expected[i], spec)
+		}
+	}
+
+	if err := sheet.SortWithHeader(r, SortSpec{Header: "Missing"}); err == nil {
+		t.Error("Expected an error for an unknown header")
+	}
+	if err := sheet.Sort(r, SortSpec{Header: "Total"}); err == nil {
+		t.Error("Expected an error when sorting by header without a header row")
+	}
+}
+
+func TestBatchSortRejectsHeaders(t *testing.T) {
+	r := SheetRange{SheetName: "Data", Range: CellRange{CellPos{0, 0}, CellPos{9, 2}}}
+
+	b := testSpreadsheet(nil).NewBatch().Sort(r, SortSpec{Column: 1})
+	if b.err != nil {
+		t.Fatalf("Unexpected error: %v", b.err)
+	}
+
+	b = testSpreadsheet(nil).NewBatch().Sort(r, SortSpec{Header: "Total"})
+	if b.err == nil {
+		t.Error("Expected an error when sorting by header in a batch")
+	}
+
+	b = testSpreadsheet(nil).NewBatch().SortWithHeader(r, SortSpec{Header: "Total"})
+	if b.err == nil {
+		t.Error("Expected an error when sorting by header in a batch")
+	}
+}
+
+func TestBatchSortWithHeader(t *testing.T) {
+	ss := testSpreadsheet(nil)
+	b := ss.NewBatch().
+		AddNamedRange("Table", SheetRange{SheetName: "Data", Range: CellRange{CellPos{0, 0}, CellPos{9, 2}}}).
+		SortWithHeader(ByName("Table"), SortSpec{Column: 1})
+	if b.err != nil {
+		t.Fatalf("Unexpected error: %v", b.err)
+	}
+
+	got := b.ops[1][0].SortRange.Range
+	if got.StartRowIndex != 1 || got.EndRowIndex != 10 {
+		t.Errorf("Wanted rows 1 to 10 sorted, but got %+v", got)
+	}
+	if named := b.named["table"]; named.StartRowIndex != 0 {
+		t.Errorf("Expected the named range to be left as is, but got %+v", named)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	retry "github.com/avast/retry-go"
@@ -45,20 +46,61 @@ func (s *Spreadsheet) GetSheet(title string) *Sheet {
 	return nil
 }
 
-func (s *Spreadsheet) DeleteSheet(title string) error {
-	query := strings.ToLower(title)
+// GetSheetExact is like GetSheet, but matches the title case-sensitively.
+func (s *Spreadsheet) GetSheetExact(title string) *Sheet {
 	for _, sheet := range s.Sheets {
-		lowerTitle := strings.ToLower(sheet.Properties.Title)
-		if lowerTitle == query {
-			_, err := s.DoBatch(&sheets.Request{
-				DeleteSheet: &sheets.DeleteSheetRequest{
-					SheetId: sheet.Properties.SheetId,
-				},
-			})
-			return err
+		if sheet.Properties.Title == title {
+			return &Sheet{sheet, s, s.Client}
 		}
 	}
-	return errors.New("sheet does not exist")
+
+	return nil
+}
+
+// SheetByID returns the sheet identified by id, as found in GridRange,
+// named ranges and batch replies.
+func (s *Spreadsheet) SheetByID(id int64) *Sheet {
+	sheet := s.sheetByID(id)
+	if sheet == nil {
+		return nil
+	}
+
+	return &Sheet{sheet, s, s.Client}
+}
+
+// AllSheets returns the spreadsheet's sheets in the order of their tabs.
+func (s *Spreadsheet) AllSheets() []*Sheet {
+	all := make([]*Sheet, len(s.Sheets))
+	for i, sheet := range s.Sheets {
+		all[i] = &Sheet{sheet, s, s.Client}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Properties.Index < all[j].Properties.Index
+	})
+
+	return all
+}
+
+func (s *Spreadsheet) DeleteSheet(title string) error {
+	sheet := s.GetSheet(title)
+	if sheet == nil {
+		return errors.New("sheet does not exist")
+	}
+
+	return s.DeleteSheetByID(sheet.Properties.SheetId)
+}
+
+func (s *Spreadsheet) DeleteSheetByID(id int64) error {
+	if s.SheetByID(id) == nil {
+		return errors.New("sheet does not exist")
+	}
+
+	_, err := s.DoBatch(&sheets.Request{
+		DeleteSheet: &sheets.DeleteSheetRequest{
+			SheetId: id,
+		},
+	})
+	return err
 }
 
 func (s *Spreadsheet) DuplicateSheet(title, newTitle string) (*Sheet, error) {
@@ -67,6 +109,15 @@ func (s *Spreadsheet) DuplicateSheet(title, newTitle string) (*Sheet, error) {
 		return nil, errors.New("origin sheet does not exist")
 	}
 
+	return s.DuplicateSheetByID(origin.Properties.SheetId, newTitle)
+}
+
+func (s *Spreadsheet) DuplicateSheetByID(id int64, newTitle string) (*Sheet, error) {
+	origin := s.SheetByID(id)
+	if origin == nil {
+		return nil, errors.New("origin sheet does not exist")
+	}
+
 	alreadyExists := s.GetSheet(newTitle)
 	if alreadyExists != nil {
 		return nil, errors.New("destination sheet already exist")
@@ -92,11 +143,9 @@ func (s *Spreadsheet) DuplicateSheet(title, newTitle string) (*Sheet, error) {
 		}
 
 		// Need to make sure that we've got the latest state of the sheet
-		currentSheet, err := s.Client.GetSpreadsheet(s.Id())
-		if err != nil {
+		if err := s.Refresh(); err != nil {
 			return nil, errors.Wrap(err, "error refreshing spreadsheet after fake duplicate error")
 		}
-		s.Spreadsheet = currentSheet.Spreadsheet
 	}
 
 	duplicate := s.GetSheet(newTitle)
@@ -180,6 +229,29 @@ func (s *Sheet) Title() string {
 	return s.Properties.Title
 }
 
+// refresh points the sheet at the spreadsheet's latest copy of its
+// properties, which DoBatch replaces.
+func (s *Sheet) refresh() {
+	for _, sheet := range s.Spreadsheet.Sheets {
+		if sheet.Properties.SheetId == s.Properties.SheetId {
+			s.Sheet = sheet
+			return
+		}
+	}
+}
+
+// doBatch runs requests against the sheet's spreadsheet and refreshes the
+// sheet's properties from the response.
+func (s *Sheet) doBatch(requests ...*sheets.Request) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	resp, err := s.Spreadsheet.DoBatch(requests...)
+	if err != nil {
+		return nil, err
+	}
+	s.refresh()
+
+	return resp, nil
+}
+
 func (s *Sheet) TopLeft() CellPos {
 	return CellPos{0, 0}
 }
@@ -212,8 +284,8 @@ func (s *Sheet) DataRange() SheetRange {
 	}
 }
 
-func (s *Sheet) Update(data [][]string) error {
-	return s.UpdateFromPosition(data, s.TopLeft())
+func (s *Sheet) Update(data [][]string, opts ...WriteOption) error {
+	return s.UpdateFromPosition(data, s.TopLeft(), opts...)
 }
 
 func (s *Sheet) GetContents() ([][]string, error) {
@@ -240,7 +312,7 @@ func (s *Sheet) GetContents() ([][]string, error) {
 	return matrix, nil
 }
 
-func (s *Sheet) UpdateFromPosition(data [][]string, start CellPos) error {
+func (s *Sheet) UpdateFromPosition(data [][]string, start CellPos, opts ...WriteOption) error {
 	// Convert to interfaces to satisfy the Google API
 	converted := make([][]interface{}, 0)
 
@@ -248,27 +320,12 @@ func (s *Sheet) UpdateFromPosition(data [][]string, start CellPos) error {
 		converted = append(converted, strToInterface(row))
 	}
 
-	return s.UpdateFromPositionIface(converted, start)
+	return s.UpdateFromPositionIface(converted, start, opts...)
 }
 
-func (s *Sheet) UpdateFromPositionIface(data [][]interface{}, start CellPos) error {
-	cellRange := start.RangeForData(data)
-
-	sheetRange := fmt.Sprintf("%s!%s", s.Title(), cellRange.String())
-
-	// TODO: Resize sheet
-	vRange := &sheets.ValueRange{
-		Range:  sheetRange,
-		Values: data,
-	}
-
-	req := s.Client.Sheets.Spreadsheets.Values.Update(s.Spreadsheet.Id(), sheetRange, vRange)
-	req.ValueInputOption("USER_ENTERED")
-
-	return googleRetry(func() error {
-		_, err := req.Do(s.Client.options...)
-		return err
-	})
+func (s *Sheet) UpdateFromPositionIface(data [][]interface{}, start CellPos, opts ...WriteOption) error {
+	_, err := s.UpdateFromPositionChunked(data, start, opts...)
+	return err
 }
 
 type ValueUpdateRequest struct {
@@ -278,41 +335,149 @@ type ValueUpdateRequest struct {
 }
 
 func (s *Sheet) BatchUpdateFromPositionIface(requests ...*ValueUpdateRequest) error {
+	return s.BatchUpdateFromPositionIfaceWithOptions(requests)
+}
+
+func (s *Sheet) BatchUpdateFromPositionIfaceWithOptions(requests []*ValueUpdateRequest, opts ...WriteOption) error {
 	if len(requests) == 0 {
 		return nil
 	}
 
+	cfg := s.Client.writeConfig(opts...)
+
 	updates := sheets.BatchUpdateValuesRequest{
-		ValueInputOption: "USER_ENTERED",
+		ValueInputOption: string(cfg.valueInput),
 	}
 
+	var end CellPos
 	for i := range requests {
+		cellRange := dataRange(requests[i].Start, requests[i].Data)
+		if cellRange.End.Row > end.Row {
+			end.Row = cellRange.End.Row
+		}
+		if cellRange.End.Col > end.Col {
+			end.Col = cellRange.End.Col
+		}
+
 		updates.Data = append(updates.Data, &sheets.ValueRange{
-			Range:  fmt.Sprintf("%s!%s", s.Title(), requests[i].Start.RangeForData(requests[i].Data).String()),
-			Values: requests[i].Data,
+			Range:  fmt.Sprintf("%s!%s", quoteSheetName(s.Title()), cellRange.String()),
+			Values: escapeValues(requests[i].Data, cfg.valueInput),
 		})
 	}
 
+	if err := s.fitGrid(end, cfg.shrinkToFit); err != nil {
+		return err
+	}
+
 	return googleRetry(func() error {
 		_, err := s.Client.Sheets.Spreadsheets.Values.BatchUpdate(s.Spreadsheet.Id(), &updates).Do(s.Client.options...)
 		return err
 	})
 }
 
-func (s *Sheet) Append(data [][]interface{}) error {
+func (s *Sheet) Append(data [][]interface{}, opts ...WriteOption) error {
+	_, err := s.AppendValues(data, opts...)
+	return err
+}
+
+// AppendValues appends data after the table found in the sheet, or in the
+// AppendAnchor range, and returns the range that was written.
+func (s *Sheet) AppendValues(data [][]interface{}, opts ...WriteOption) (SheetRange, error) {
+	cfg := s.Client.writeConfig(opts...)
+
+	anchor := quoteSheetName(s.Title())
+	if cfg.anchor != nil {
+		anchor = fmt.Sprintf("%s!%s", anchor, cfg.anchor.String())
+	}
+
 	req := s.Client.Sheets.Spreadsheets.Values.Append(
 		s.Spreadsheet.Id(),
-		s.DataRange().String(),
+		anchor,
 		&sheets.ValueRange{
-			Values: data,
+			Values: escapeValues(data, cfg.valueInput),
 		},
 	)
-	req.ValueInputOption("USER_ENTERED")
+	req.ValueInputOption(string(cfg.valueInput))
+	req.InsertDataOption(string(cfg.insertData))
 
-	return googleRetry(func() error {
+	var resp *sheets.AppendValuesResponse
+	err := googleRetry(func() error {
+		var rerr error
+		resp, rerr = req.Do(s.Client.options...)
+		return rerr
+	})
+	if err != nil {
+		return SheetRange{}, err
+	}
+
+	if resp.Updates == nil || resp.Updates.UpdatedRange == "" {
+		return SheetRange{}, errors.New("append response is missing the updated range")
+	}
+
+	updated, err := ParseSheetRange(resp.Updates.UpdatedRange)
+	if err != nil {
+		return SheetRange{}, errors.Wrap(err, "couldn't parse appended range")
+	}
+
+	return updated, nil
+}
+
+// AppendStructs appends one row per element of rows, a slice of structs
+// encoded with StructsToRows, and returns the range that was written.
+func (s *Sheet) AppendStructs(rows interface{}, opts ...WriteOption) (SheetRange, error) {
+	_, data, err := StructsToRows(rows)
+	if err != nil {
+		return SheetRange{}, err
+	}
+	if len(data) == 0 {
+		return SheetRange{}, errors.New("no rows to append")
+	}
+
+	return s.AppendValues(data, opts...)
+}
+
+// GetRange reads the formatted values of r, which may be a named range.
+// Trailing empty rows and cells are omitted.
+func (s *Spreadsheet) GetRange(r SheetRange) ([][]string, error) {
+	var resp *sheets.ValueRange
+	err := googleRetry(func() error {
+		var rerr error
+		resp, rerr = s.Client.Sheets.Spreadsheets.Values.Get(s.Id(), r.String()).
+			MajorDimension("ROWS").Do(s.Client.options...)
+
+		return rerr
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read %s", r.String())
+	}
+
+	values := make([][]string, len(resp.Values))
+	for i, row := range resp.Values {
+		values[i] = ifaceToStr(row)
+	}
+
+	return values, nil
+}
+
+// UpdateRange writes data at the top left of r, which may be a named range.
+// data must fit in r.
+func (s *Spreadsheet) UpdateRange(r SheetRange, data [][]interface{}, opts ...WriteOption) error {
+	cfg := s.Client.writeConfig(opts...)
+
+	req := s.Client.Sheets.Spreadsheets.Values.Update(s.Id(), r.String(), &sheets.ValueRange{
+		Values: escapeValues(data, cfg.valueInput),
+	})
+	req.ValueInputOption(string(cfg.valueInput))
+
+	err := googleRetry(func() error {
 		_, err := req.Do(s.Client.options...)
 		return err
 	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update %s", r.String())
+	}
+
+	return nil
 }
 
 func (s *Spreadsheet) DoBatch(requests ...*sheets.Request) (*sheets.BatchUpdateSpreadsheetResponse, error) {
@@ -320,9 +485,10 @@ func (s *Spreadsheet) DoBatch(requests ...*sheets.Request) (*sheets.BatchUpdateS
 		return nil, nil
 	}
 
+	// Stale spreadsheets are refreshed by the response
 	batchUpdateReq := sheets.BatchUpdateSpreadsheetRequest{
 		Requests:                     requests,
-		IncludeSpreadsheetInResponse: true,
+		IncludeSpreadsheetInResponse: !s.Client.LightweightBatches || s.Stale(),
 	}
 
 	var resp *sheets.BatchUpdateSpreadsheetResponse
@@ -335,7 +501,12 @@ func (s *Spreadsheet) DoBatch(requests ...*sheets.Request) (*sheets.BatchUpdateS
 		return nil, err
 	}
 
-	s.Spreadsheet = resp.UpdatedSpreadsheet
+	if resp.UpdatedSpreadsheet != nil {
+		s.Client.setStale(s.Spreadsheet, false)
+		s.Spreadsheet = resp.UpdatedSpreadsheet
+	} else {
+		s.applyLocally(requests, resp.Replies)
+	}
 
 	return resp, nil
 }
@@ -3,6 +3,8 @@ package sheets
 import (
 	"strings"
 	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
 )
 
 var tsvTests = []struct {
@@ -38,3 +40,35 @@ func TestTsvToArr(t *testing.T) {
 		}
 	}
 }
+
+func TestSheetLookups(t *testing.T) {
+	ss := &Spreadsheet{Spreadsheet: &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{
+			{Properties: &sheets.SheetProperties{SheetId: 5, Index: 1, Title: "data"}},
+			{Properties: &sheets.SheetProperties{SheetId: 0, Index: 2, Title: "Summary"}},
+			{Properties: &sheets.SheetProperties{SheetId: 9, Index: 0, Title: "Data"}},
+		},
+	}}
+
+	if sheet := ss.SheetByID(5); sheet == nil || sheet.Title() != "data" {
+		t.Errorf("Unexpected sheet %v for ID 5", sheet)
+	}
+	if sheet := ss.SheetByID(6); sheet != nil {
+		t.Errorf("Unexpected sheet %v for ID 6", sheet.Title())
+	}
+
+	if sheet := ss.GetSheetExact("Data"); sheet == nil || sheet.Properties.SheetId != 9 {
+		t.Errorf("Unexpected sheet %v for Data", sheet)
+	}
+	if sheet := ss.GetSheetExact("summary"); sheet != nil {
+		t.Errorf("Unexpected sheet %v for summary", sheet.Title())
+	}
+
+	var titles []string
+	for _, sheet := range ss.AllSheets() {
+		titles = append(titles, sheet.Title())
+	}
+	if strings.Join(titles, ",") != "Data,data,Summary" {
+		t.Errorf("Wanted sheets in tab order, but got %v", titles)
+	}
+}
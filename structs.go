@@ -0,0 +1,157 @@
+package sheets
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// StructsToRows encodes a slice of structs, or of pointers to structs, to
+// rows of cell values. Each exported field is a column, in declaration order,
+// and fields of embedded structs are inlined. The `sheets` tag sets the
+// column's header name, or skips the field when set to "-".
+func StructsToRows(v interface{}) ([]string, [][]interface{}, error) {
+	slice := reflect.ValueOf(v)
+	if slice.Kind() != reflect.Slice {
+		return nil, nil, errors.Errorf("expected a slice of structs, got %T", v)
+	}
+
+	elemType := slice.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, nil, errors.Errorf("expected a slice of structs, got %T", v)
+	}
+
+	fields := structColumns(elemType, nil)
+
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+
+	rows := make([][]interface{}, slice.Len())
+	for i := range rows {
+		elem := slice.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				rows[i] = make([]interface{}, len(fields))
+				for j := range rows[i] {
+					rows[i][j] = ""
+				}
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		row := make([]interface{}, len(fields))
+		for j, f := range fields {
+			row[j] = cellValue(fieldByIndex(elem, f.index))
+		}
+		rows[i] = row
+	}
+
+	return header, rows, nil
+}
+
+type structColumn struct {
+	name  string
+	index []int
+}
+
+func structColumns(t reflect.Type, parent []int) []structColumn {
+	var columns []structColumn
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		index := append(append([]int(nil), parent...), i)
+
+		tag := f.Tag.Get("sheets")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				columns = append(columns, structColumns(ft, index)...)
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			// Unexported
+			continue
+		}
+
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		columns = append(columns, structColumn{name: name, index: index})
+	}
+
+	return columns
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but returns an invalid
+// value instead of panicking on nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+
+	return v
+}
+
+func cellValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		return cellValue(v.Elem())
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format("2006-01-02 15:04:05")
+	case Literal:
+		return value
+	case fmt.Stringer:
+		return value.String()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+
+	return fmt.Sprint(v.Interface())
+}
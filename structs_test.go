@@ -0,0 +1,96 @@
+package sheets
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+type testAudit struct {
+	CreatedAt time.Time `sheets:"Created"`
+}
+
+type testRecord struct {
+	ID     string
+	Amount float64 `sheets:"Total"`
+	Count  *int
+	Secret string `sheets:"-"`
+	Note   Literal
+	hidden string
+	*testAudit
+}
+
+func TestStructsToRows(t *testing.T) {
+	count := 3
+	created := time.Date(2020, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	header, rows, err := StructsToRows([]*testRecord{
+		{ID: "00123", Amount: 1.5, Count: &count, Secret: "s", Note: "=x", hidden: "h", testAudit: &testAudit{created}},
+		{ID: "b"},
+		nil,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantHeader := []string{"ID", "Total", "Count", "Note", "Created"}
+	if !reflect.DeepEqual(header, wantHeader) {
+		t.Errorf("Wanted header %v, but got %v", wantHeader, header)
+	}
+
+	wantRows := [][]interface{}{
+		{"00123", 1.5, int64(3), Literal("=x"), "2020-06-01 12:30:00"},
+		{"b", 0.0, "", Literal(""), ""},
+		{"", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("Wanted rows %#v, but got %#v", wantRows, rows)
+	}
+
+	if _, _, err := StructsToRows([]string{"a"}); err == nil {
+		t.Error("Expected error, but got none")
+	}
+}
+
+func TestAppendStructs(t *testing.T) {
+	var (
+		gotQuery  map[string][]string
+		gotValues [][]interface{}
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+
+		var vr sheets.ValueRange
+		json.NewDecoder(r.Body).Decode(&vr)
+		gotValues = vr.Values
+
+		json.NewEncoder(w).Encode(&sheets.AppendValuesResponse{
+			Updates: &sheets.UpdateValuesResponse{UpdatedRange: "'My data'!A5:E6"},
+		})
+	}))
+
+	ss := &Spreadsheet{client, &sheets.Spreadsheet{SpreadsheetId: "ssid"}}
+	sheet := &Sheet{&sheets.Sheet{Properties: &sheets.SheetProperties{Title: "My data"}}, ss, client}
+
+	got, err := sheet.AppendStructs([]testRecord{{ID: "a"}, {ID: "b", Note: "=x"}}, InsertData(InsertRows))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := SheetRange{SheetName: "My data", Range: CellRange{CellPos{4, 0}, CellPos{5, 4}}}
+	if got != expected {
+		t.Errorf("Wanted %+v, but got %+v", expected, got)
+	}
+
+	if gotQuery["insertDataOption"][0] != "INSERT_ROWS" || gotQuery["valueInputOption"][0] != "USER_ENTERED" {
+		t.Errorf("Unexpected query %v", gotQuery)
+	}
+
+	if len(gotValues) != 2 || gotValues[1][3] != "'=x" {
+		t.Errorf("Unexpected values %v", gotValues)
+	}
+}
@@ -0,0 +1,410 @@
+package sheets
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// TemplateData holds the values substituted in a template, by token name.
+// Values bound to repeating blocks are slices of structs, encoded as by
+// StructsToRows, or of maps.
+type TemplateData map[string]interface{}
+
+var (
+	templateToken      = regexp.MustCompile(`\{\{\s*([^{}#/\s][^{}]*?)\s*\}\}`)
+	templateBlockStart = regexp.MustCompile(`\{\{\s*#\s*([^{}\s]+)\s*\}\}`)
+	templateBlockEnd   = regexp.MustCompile(`\{\{\s*/\s*([^{}\s]+)\s*\}\}`)
+)
+
+// FillTemplate copies the spreadsheet templateID as newName, fills it with
+// data and returns the copy. It takes three API calls: the copy, a read of
+// the template's cells and a single batchUpdate.
+//
+// {{name}} tokens in cell values and formulas of every sheet are replaced by
+// data["name"]. A cell made of a single token takes the value's type, e.g. a
+// number. Tokens without a value are left as is.
+//
+// Rows from one containing {{#items}} to one containing {{/items}}, which can
+// be the same row, are a block repeated for each element of data["items"].
+// Tokens in the block are looked up in the element first, so that
+// {{price}} is the element's Price field or "price" key. Blocks are copied
+// with their formatting and formulas, whose relative references follow the
+// copy, and are deleted when the slice is empty.
+//
+//	ss, err := client.FillTemplate(templateID, "Invoice 42", TemplateData{
+//		"customer": "Acme",
+//		"lines":    invoice.Lines,
+//	})
+//
+// If filling fails, the copy is deleted.
+func (c *Client) FillTemplate(templateID, newName string, data TemplateData) (*Spreadsheet, error) {
+	id, err := c.copyFile(templateID, newName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't copy template %s", templateID)
+	}
+
+	ss, err := c.fillTemplate(id, data)
+	if err != nil {
+		_ = c.Delete(id)
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+func (c *Client) fillTemplate(id string, data TemplateData) (*Spreadsheet, error) {
+	ss, err := c.GetSpreadsheetWithData(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't read template copy %s", id)
+	}
+
+	var requests []*sheets.Request
+	for _, sheet := range ss.Sheets {
+		sheetRequests, err := templateRequests(sheet, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't fill sheet %s", sheet.Properties.Title)
+		}
+		requests = append(requests, sheetRequests...)
+
+		// The values are out of date once filled
+		sheet.Data = nil
+	}
+
+	if _, err := ss.DoBatch(requests...); err != nil {
+		return nil, errors.Wrapf(err, "couldn't fill template copy %s", id)
+	}
+
+	return ss, nil
+}
+
+type templateBlock struct {
+	name       string
+	start, end int
+}
+
+// templateRequests returns the requests filling the sheet. Plain cells are
+// filled first, then blocks from the bottom up so that inserting rows doesn't
+// shift the blocks still to be filled.
+func templateRequests(sheet *sheets.Sheet, data TemplateData) ([]*sheets.Request, error) {
+	if len(sheet.Data) == 0 {
+		return nil, nil
+	}
+	grid := sheet.Data[0]
+	sheetID := sheet.Properties.SheetId
+
+	blocks, err := findTemplateBlocks(grid.RowData)
+	if err != nil {
+		return nil, err
+	}
+
+	inBlock := make(map[int]bool)
+	for _, block := range blocks {
+		for row := block.start; row <= block.end; row++ {
+			inBlock[row] = true
+		}
+	}
+
+	var requests []*sheets.Request
+	for i, row := range grid.RowData {
+		if inBlock[i] {
+			continue
+		}
+
+		for j, cell := range row.Values {
+			value, changed := fillValue(cell.UserEnteredValue, data)
+			if !changed {
+				continue
+			}
+
+			requests = append(requests, updateCellsRequest(sheetID, grid.StartRow+int64(i), grid.StartColumn+int64(j),
+				[]*sheets.RowData{{Values: []*sheets.CellData{{UserEnteredValue: value}}}}, "userEnteredValue"))
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].start > blocks[j].start })
+	for _, block := range blocks {
+		blockRequests, err := blockRequests(sheetID, grid, block, data)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, blockRequests...)
+	}
+
+	return requests, nil
+}
+
+// blockRequests repeats the block for each item. Each copy is pasted from
+// the block once filled with its item, so that relative references in
+// formulas, tokens included, point at the copy's rows.
+func blockRequests(sheetID int64, grid *sheets.GridData, block templateBlock, data TemplateData) ([]*sheets.Request, error) {
+	items, err := templateItems(data[block.name])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value for block %s", block.name)
+	}
+
+	start := grid.StartRow + int64(block.start)
+	height := int64(block.end - block.start + 1)
+
+	if len(items) == 0 {
+		return []*sheets.Request{
+			deleteDimensionRequest(sheetID, DimensionRows, int(start), int(height)),
+		}, nil
+	}
+
+	// fill writes the cells of the block that contain tokens
+	fill := func(item map[string]interface{}) []*sheets.Request {
+		var requests []*sheets.Request
+		for i, row := range grid.RowData[block.start : block.end+1] {
+			for j, cell := range row.Values {
+				value, changed := fillValue(cell.UserEnteredValue, item, data)
+				if !changed {
+					continue
+				}
+
+				requests = append(requests, updateCellsRequest(sheetID, start+int64(i), grid.StartColumn+int64(j),
+					[]*sheets.RowData{{Values: []*sheets.CellData{{UserEnteredValue: value}}}}, "userEnteredValue"))
+			}
+		}
+
+		return requests
+	}
+
+	var requests []*sheets.Request
+	if len(items) > 1 {
+		requests = append(requests, insertDimensionRequest(sheetID, DimensionRows, int(start+height),
+			int(height*int64(len(items)-1)), true))
+	}
+
+	for i := 1; i < len(items); i++ {
+		dest := start + height*int64(i)
+		requests = append(requests, fill(items[i])...)
+		requests = append(requests, &sheets.Request{
+			CopyPaste: &sheets.CopyPasteRequest{
+				Source: &sheets.GridRange{
+					SheetId:       sheetID,
+					StartRowIndex: start,
+					EndRowIndex:   start + height,
+				},
+				Destination: &sheets.GridRange{
+					SheetId:       sheetID,
+					StartRowIndex: dest,
+					EndRowIndex:   dest + height,
+				},
+				PasteType: "PASTE_NORMAL",
+			},
+		})
+	}
+
+	// The block itself is the first item's
+	requests = append(requests, fill(items[0])...)
+
+	return requests, nil
+}
+
+func updateCellsRequest(sheetID, row, col int64, rows []*sheets.RowData, fields string) *sheets.Request {
+	return &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:         sheetID,
+				RowIndex:        row,
+				ColumnIndex:     col,
+				ForceSendFields: []string{"SheetId", "RowIndex", "ColumnIndex"},
+			},
+			Rows:   rows,
+			Fields: fields,
+		},
+	}
+}
+
+// findTemplateBlocks returns the blocks marked in rows. Blocks can't be
+// nested.
+func findTemplateBlocks(rows []*sheets.RowData) ([]templateBlock, error) {
+	var (
+		blocks []templateBlock
+		open   *templateBlock
+	)
+
+	for i, row := range rows {
+		for _, cell := range row.Values {
+			text := templateText(cell.UserEnteredValue)
+
+			for _, m := range templateBlockStart.FindAllStringSubmatch(text, -1) {
+				if open != nil {
+					return nil, errors.Errorf("block %s starts inside block %s", m[1], open.name)
+				}
+				open = &templateBlock{name: m[1], start: i}
+			}
+		}
+
+		for _, cell := range row.Values {
+			text := templateText(cell.UserEnteredValue)
+
+			for _, m := range templateBlockEnd.FindAllStringSubmatch(text, -1) {
+				if open == nil || open.name != m[1] {
+					return nil, errors.Errorf("block %s ends without starting", m[1])
+				}
+				open.end = i
+				blocks = append(blocks, *open)
+				open = nil
+			}
+		}
+	}
+
+	if open != nil {
+		return nil, errors.Errorf("block %s is never closed", open.name)
+	}
+
+	return blocks, nil
+}
+
+// fillValue substitutes the tokens of value, looking them up in scopes in
+// order, and removes block markers. It reports whether value changed.
+func fillValue(value *sheets.ExtendedValue, scopes ...map[string]interface{}) (*sheets.ExtendedValue, bool) {
+	text := templateText(value)
+	if !strings.Contains(text, "{{") {
+		return value, false
+	}
+
+	text = templateBlockStart.ReplaceAllString(text, "")
+	text = templateBlockEnd.ReplaceAllString(text, "")
+
+	// A cell made of a single token keeps the value's type
+	if value.FormulaValue == nil {
+		if m := templateToken.FindStringSubmatch(text); m != nil && m[0] == strings.TrimSpace(text) {
+			if v, ok := lookupToken(m[1], scopes); ok {
+				return extendedValue(v), true
+			}
+		}
+	}
+
+	filled := templateToken.ReplaceAllStringFunc(text, func(token string) string {
+		name := templateToken.FindStringSubmatch(token)[1]
+		if v, ok := lookupToken(name, scopes); ok {
+			return fmt.Sprint(v)
+		}
+		return token
+	})
+
+	if value.FormulaValue != nil {
+		return &sheets.ExtendedValue{FormulaValue: &filled}, true
+	}
+	if filled == "" {
+		return nil, true
+	}
+
+	return &sheets.ExtendedValue{StringValue: &filled}, true
+}
+
+func lookupToken(name string, scopes []map[string]interface{}) (interface{}, bool) {
+	for _, scope := range scopes {
+		if v, ok := scope[name]; ok {
+			return cellValue(reflect.ValueOf(v)), true
+		}
+	}
+
+	return nil, false
+}
+
+// templateText returns the text of a cell that can contain tokens.
+func templateText(value *sheets.ExtendedValue) string {
+	switch {
+	case value == nil:
+		return ""
+	case value.FormulaValue != nil:
+		return *value.FormulaValue
+	case value.StringValue != nil:
+		return *value.StringValue
+	}
+
+	return ""
+}
+
+// templateItems converts the value bound to a block to one scope per element.
+func templateItems(v interface{}) ([]map[string]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch items := v.(type) {
+	case []map[string]interface{}:
+		return items, nil
+	case []TemplateData:
+		scopes := make([]map[string]interface{}, len(items))
+		for i, item := range items {
+			scopes[i] = item
+		}
+		return scopes, nil
+	}
+
+	header, rows, err := StructsToRows(v)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		scope := make(map[string]interface{}, len(header))
+		for j, name := range header {
+			scope[name] = row[j]
+		}
+		scopes[i] = scope
+	}
+
+	return scopes, nil
+}
+
+// extendedValue converts a value as accepted by the values API to cell data,
+// as written with ValueInputRaw.
+func extendedValue(value interface{}) *sheets.ExtendedValue {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case Literal:
+		str := string(v)
+		return &sheets.ExtendedValue{StringValue: &str}
+	case string:
+		if v == "" {
+			return nil
+		}
+		return &sheets.ExtendedValue{StringValue: &v}
+	case bool:
+		return &sheets.ExtendedValue{BoolValue: &v}
+	case int:
+		return numberValue(float64(v))
+	case int8:
+		return numberValue(float64(v))
+	case int16:
+		return numberValue(float64(v))
+	case int32:
+		return numberValue(float64(v))
+	case int64:
+		return numberValue(float64(v))
+	case uint:
+		return numberValue(float64(v))
+	case uint8:
+		return numberValue(float64(v))
+	case uint16:
+		return numberValue(float64(v))
+	case uint32:
+		return numberValue(float64(v))
+	case uint64:
+		return numberValue(float64(v))
+	case float32:
+		return numberValue(float64(v))
+	case float64:
+		return numberValue(v)
+	}
+
+	str := fmt.Sprint(value)
+	return &sheets.ExtendedValue{StringValue: &str}
+}
+
+func numberValue(f float64) *sheets.ExtendedValue {
+	return &sheets.ExtendedValue{NumberValue: &f}
+}
@@ -0,0 +1,192 @@
+package sheets
+
+import (
+	"encoding/json"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func stringCell(s string) *sheets.CellData {
+	return &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{StringValue: &s}}
+}
+
+func formulaCell(f string) *sheets.CellData {
+	return &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &f}}
+}
+
+func templateSheet(rows ...[]*sheets.CellData) *sheets.Sheet {
+	grid := &sheets.GridData{}
+	for _, row := range rows {
+		grid.RowData = append(grid.RowData, &sheets.RowData{Values: row})
+	}
+
+	return &sheets.Sheet{
+		Properties: &sheets.SheetProperties{SheetId: 3, Title: "Invoice"},
+		Data:       []*sheets.GridData{grid},
+	}
+}
+
+type invoiceLine struct {
+	Item  string  `sheets:"item"`
+	Price float64 `sheets:"price"`
+}
+
+func TestFillValue(t *testing.T) {
+	data := TemplateData{"name": "Acme", "total": 12.5}
+
+	for _, tt := range []struct {
+		cell     *sheets.CellData
+		expected string
+	}{
+		{stringCell("Dear {{ name }},"), "Dear Acme,"},
+		{stringCell("{{total}}"), "12.5"},
+		{stringCell("{{missing}}"), "{{missing}}"},
+		{stringCell("{{#lines}}"), ""},
+		{formulaCell(`=CONCAT("{{name}}", A1)`), `=CONCAT("Acme", A1)`},
+	} {
+		value, _ := fillValue(tt.cell.UserEnteredValue, data)
+
+		got := ""
+		switch {
+		case value == nil:
+		case value.NumberValue != nil:
+			got = formatNumber(*value.NumberValue)
+		case value.FormulaValue != nil:
+			got = *value.FormulaValue
+		case value.StringValue != nil:
+			got = *value.StringValue
+		}
+
+		if got != tt.expected {
+			t.Errorf("Wanted %q, but got %q", tt.expected, got)
+		}
+	}
+
+	if value, _ := fillValue(stringCell("{{total}}").UserEnteredValue, data); value.NumberValue == nil {
+		t.Error("Expected a single token cell to keep the value's type")
+	}
+}
+
+func TestTemplateRequests(t *testing.T) {
+	sheet := templateSheet(
+		[]*sheets.CellData{stringCell("Invoice for {{customer}}")},
+		[]*sheets.CellData{stringCell("Item"), stringCell("Price")},
+		[]*sheets.CellData{stringCell("{{#lines}}{{item}}"), stringCell("{{price}}{{/lines}}"),
+			formulaCell("=B3*1.2"), formulaCell("=B3*{{rate}}")},
+		[]*sheets.CellData{stringCell("{{#notes}}"), {}},
+		[]*sheets.CellData{stringCell("{{text}}{{/notes}}")},
+		[]*sheets.CellData{stringCell("Total"), formulaCell("=SUM(B3:B3)")},
+	)
+	data := TemplateData{
+		"customer": "Acme",
+		"rate":     1.5,
+		"lines":    []invoiceLine{{"Widget", 2}, {"Gadget", 3.5}, {"Gizmo", 1}},
+		"notes":    nil,
+	}
+
+	requests, err := templateRequests(sheet, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// customer cell, then notes deleted, then lines expanded: rows inserted,
+	// and for the second and third lines, the block filled and pasted, then
+	// the block filled with the first line
+	if len(requests) != 14 {
+		t.Fatalf("Wanted 14 requests, but got %d", len(requests))
+	}
+
+	if got := *requests[0].UpdateCells.Rows[0].Values[0].UserEnteredValue.StringValue; got != "Invoice for Acme" {
+		t.Errorf("Wanted Invoice for Acme, but got %s", got)
+	}
+
+	if del := requests[1].DeleteDimension; del == nil || del.Range.StartIndex != 3 || del.Range.EndIndex != 5 {
+		t.Errorf("Wanted rows 3 to 5 deleted, but got %+v", requests[1])
+	}
+
+	if ins := requests[2].InsertDimension; ins == nil || ins.Range.StartIndex != 3 || ins.Range.EndIndex != 5 {
+		t.Errorf("Wanted 2 rows inserted at 3, but got %+v", requests[2])
+	}
+
+	for i, tt := range []struct {
+		item    string
+		price   float64
+		formula string
+	}{
+		{"Gadget", 3.5, "=B3*1.5"},
+		{"Gizmo", 1, "=B3*1.5"},
+		{"Widget", 2, "=B3*1.5"},
+	} {
+		fills := requests[3+4*i : 6+4*i]
+		for _, req := range fills {
+			if req.UpdateCells == nil || req.UpdateCells.Start.RowIndex != 2 {
+				t.Fatalf("Wanted the block filled on row 2, but got %+v", req)
+			}
+		}
+
+		if got := *fills[0].UpdateCells.Rows[0].Values[0].UserEnteredValue.StringValue; got != tt.item {
+			t.Errorf("Wanted %s, but got %s", tt.item, got)
+		}
+		if got := *fills[1].UpdateCells.Rows[0].Values[0].UserEnteredValue.NumberValue; got != tt.price {
+			t.Errorf("Wanted %v, but got %v", tt.price, got)
+		}
+		// Formulas without tokens are left to the paste, which shifts them
+		if col := fills[2].UpdateCells.Start.ColumnIndex; col != 3 {
+			t.Errorf("Wanted the formula in column 3 filled, but got column %d", col)
+		}
+		if got := *fills[2].UpdateCells.Rows[0].Values[0].UserEnteredValue.FormulaValue; got != tt.formula {
+			t.Errorf("Wanted %s, but got %s", tt.formula, got)
+		}
+
+		if i == 2 {
+			break
+		}
+		cp := requests[6+4*i].CopyPaste
+		if cp == nil || cp.PasteType != "PASTE_NORMAL" || cp.Source.StartRowIndex != 2 ||
+			cp.Destination.StartRowIndex != int64(3+i) || cp.Destination.EndRowIndex != int64(4+i) {
+			t.Errorf("Wanted the block pasted on row %d, but got %+v", 3+i, requests[6+4*i])
+		}
+	}
+}
+
+func TestFindTemplateBlocksErrors(t *testing.T) {
+	for _, rows := range [][][]*sheets.CellData{
+		{{stringCell("{{#a}}")}},
+		{{stringCell("{{/a}}")}},
+		{{stringCell("{{#a}}")}, {stringCell("{{#b}}")}},
+	} {
+		if _, err := findTemplateBlocks(templateSheet(rows...).Data[0].RowData); err == nil {
+			t.Errorf("Expected an error for %v", rows)
+		}
+	}
+}
+
+var extendedValueTests = []struct {
+	value    interface{}
+	expected string
+}{
+	{nil, "null"},
+	{"", "null"},
+	{"00123", `{"stringValue":"00123"}`},
+	{Literal("00123"), `{"stringValue":"00123"}`},
+	{"=SUM(A:A)", `{"stringValue":"=SUM(A:A)"}`},
+	{false, `{"boolValue":false}`},
+	{0, `{"numberValue":0}`},
+	{int64(-3), `{"numberValue":-3}`},
+	{1.5, `{"numberValue":1.5}`},
+	{[]int{1}, `{"stringValue":"[1]"}`},
+}
+
+func TestExtendedValue(t *testing.T) {
+	for _, tt := range extendedValueTests {
+		encoded, err := json.Marshal(extendedValue(tt.value))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if string(encoded) != tt.expected {
+			t.Errorf("Wanted %s, but got %s for %#v", tt.expected, encoded, tt.value)
+		}
+	}
+}
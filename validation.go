@@ -0,0 +1,142 @@
+package sheets
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+// Validation restricts the values that can be entered in cells to the ones
+// matching Condition. Constructors return strict validations, which reject
+// invalid values.
+type Validation struct {
+	Condition Condition
+
+	// Strict rejects invalid values. Otherwise they're accepted but flagged
+	// with a warning.
+	Strict bool
+	// ShowDropdown displays a dropdown in cells validated by a list or a range
+	ShowDropdown bool
+	// InputMessage is shown when a validated cell is selected
+	InputMessage string
+}
+
+// ValidateList only accepts values from a list, picked from a dropdown.
+func ValidateList(values ...string) *Validation {
+	return &Validation{
+		Condition:    Condition{"ONE_OF_LIST", values},
+		Strict:       true,
+		ShowDropdown: true,
+	}
+}
+
+// ValidateRange only accepts values found in the cells of r, picked from a
+// dropdown.
+func ValidateRange(r SheetRange) *Validation {
+	return &Validation{
+		Condition:    Condition{"ONE_OF_RANGE", []string{"=" + r.quotedA1()}},
+		Strict:       true,
+		ShowDropdown: true,
+	}
+}
+
+// ValidateCheckbox displays cells as checkboxes.
+func ValidateCheckbox() *Validation {
+	return &Validation{
+		Condition: Condition{Type: "BOOLEAN"},
+		Strict:    true,
+	}
+}
+
+func ValidateNumberBetween(min, max float64) *Validation {
+	return ValidateCondition(NumberBetween(min, max))
+}
+
+func ValidateDateBetween(from, to time.Time) *Validation {
+	return ValidateCondition(Condition{"DATE_BETWEEN", []string{formatDate(from), formatDate(to)}})
+}
+
+func ValidateDateAfter(t time.Time) *Validation {
+	return ValidateCondition(Condition{"DATE_AFTER", []string{formatDate(t)}})
+}
+
+func ValidateDateBefore(t time.Time) *Validation {
+	return ValidateCondition(Condition{"DATE_BEFORE", []string{formatDate(t)}})
+}
+
+// ValidateFormula only accepts values for which formula evaluates to true.
+func ValidateFormula(formula string) *Validation {
+	return ValidateCondition(CustomFormula(formula))
+}
+
+// ValidateCondition only accepts values matching cond.
+func ValidateCondition(cond Condition) *Validation {
+	return &Validation{Condition: cond, Strict: true}
+}
+
+// Warning makes the validation accept invalid values with a warning.
+func (v *Validation) Warning() *Validation {
+	v.Strict = false
+	return v
+}
+
+// WithMessage sets the message shown when a validated cell is selected.
+func (v *Validation) WithMessage(msg string) *Validation {
+	v.InputMessage = msg
+	return v
+}
+
+func (v *Validation) rule() *sheets.DataValidationRule {
+	return &sheets.DataValidationRule{
+		Condition:    v.Condition.booleanCondition(),
+		Strict:       v.Strict,
+		ShowCustomUi: v.ShowDropdown,
+		InputMessage: v.InputMessage,
+	}
+}
+
+// SetDataValidation validates the values entered in the cells of r,
+// replacing any existing validation.
+func (s *Sheet) SetDataValidation(r CellRange, v *Validation) error {
+	_, err := s.doBatch(dataValidationRequest(r.GridRange(s.Properties.SheetId), v))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't set data validation on %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// ClearDataValidation removes the validation of the cells of r.
+func (s *Sheet) ClearDataValidation(r CellRange) error {
+	_, err := s.doBatch(dataValidationRequest(r.GridRange(s.Properties.SheetId), nil))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't clear data validation on %s!%s", s.Title(), r.String())
+	}
+
+	return nil
+}
+
+// SetDataValidation validates the values entered in the cells of r, or clears
+// their validation if v is nil.
+func (b *Batch) SetDataValidation(r SheetRange, v *Validation) *Batch {
+	gridRange, err := b.gridRange(r)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Add(dataValidationRequest(gridRange, v))
+}
+
+func dataValidationRequest(gridRange *sheets.GridRange, v *Validation) *sheets.Request {
+	req := &sheets.SetDataValidationRequest{Range: gridRange}
+	if v != nil {
+		req.Rule = v.rule()
+	}
+
+	return &sheets.Request{SetDataValidation: req}
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
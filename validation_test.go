@@ -0,0 +1,63 @@
+package sheets
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var validationTests = []struct {
+	validation *Validation
+	expected   string
+}{
+	{
+		ValidateList("todo", "done"),
+		`{"condition":{"type":"ONE_OF_LIST","values":[{"userEnteredValue":"todo"},{"userEnteredValue":"done"}]},"showCustomUi":true,"strict":true}`,
+	},
+	{
+		ValidateRange(SheetRange{SheetName: "Lists", Range: CellRange{CellPos{0, 0}, CellPos{9, 0}}}),
+		`{"condition":{"type":"ONE_OF_RANGE","values":[{"userEnteredValue":"='Lists'!A1:A10"}]},"showCustomUi":true,"strict":true}`,
+	},
+	{
+		ValidateRange(SheetRange{SheetName: "My Lists", Range: CellRange{CellPos{0, 0}, CellPos{9, 0}}}),
+		`{"condition":{"type":"ONE_OF_RANGE","values":[{"userEnteredValue":"='My Lists'!A1:A10"}]},"showCustomUi":true,"strict":true}`,
+	},
+	{
+		ValidateRange(SheetRange{SheetName: "Bob's Lists", Range: CellRange{CellPos{1, 1}, CellPos{4, 2}}}),
+		`{"condition":{"type":"ONE_OF_RANGE","values":[{"userEnteredValue":"='Bob''s Lists'!B2:C5"}]},"showCustomUi":true,"strict":true}`,
+	},
+	{
+		ValidateRange(ByName("Statuses")),
+		`{"condition":{"type":"ONE_OF_RANGE","values":[{"userEnteredValue":"=Statuses"}]},"showCustomUi":true,"strict":true}`,
+	},
+	{
+		ValidateCheckbox(),
+		`{"condition":{"type":"BOOLEAN"},"strict":true}`,
+	},
+	{
+		ValidateNumberBetween(0, 1.5).Warning().WithMessage("A ratio"),
+		`{"condition":{"type":"NUMBER_BETWEEN","values":[{"userEnteredValue":"0"},{"userEnteredValue":"1.5"}]},"inputMessage":"A ratio"}`,
+	},
+	{
+		ValidateDateAfter(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+		`{"condition":{"type":"DATE_AFTER","values":[{"userEnteredValue":"2020-06-01"}]},"strict":true}`,
+	},
+}
+
+func TestValidationRule(t *testing.T) {
+	for _, tt := range validationTests {
+		encoded, err := json.Marshal(tt.validation.rule())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if string(encoded) != tt.expected {
+			t.Errorf("Wanted %s, but got %s", tt.expected, encoded)
+		}
+	}
+
+	clear := dataValidationRequest(CellRange{}.GridRange(1), nil)
+	if clear.SetDataValidation.Rule != nil {
+		t.Error("Expected no rule when clearing validation")
+	}
+}
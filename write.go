@@ -0,0 +1,469 @@
+package sheets
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+const (
+	// Google recommends keeping payloads under 2MB, and rejects requests
+	// that are much larger than that.
+	defaultChunkMaxBytes = 2 << 20
+	defaultChunkMaxCells = 100000
+)
+
+// ValueInputOption controls how written values are interpreted.
+type ValueInputOption string
+
+const (
+	// ValueInputUserEntered parses values as if they were typed in the UI:
+	// "00123" becomes a number and "=A1" a formula.
+	ValueInputUserEntered ValueInputOption = "USER_ENTERED"
+	// ValueInputRaw stores values as-is.
+	ValueInputRaw ValueInputOption = "RAW"
+)
+
+// InsertDataOption controls how appended values are added to a table.
+type InsertDataOption string
+
+const (
+	// InsertRows inserts new rows for the appended values.
+	InsertRows InsertDataOption = "INSERT_ROWS"
+	// Overwrite writes appended values over the cells after the table.
+	Overwrite InsertDataOption = "OVERWRITE"
+)
+
+// Literal is a cell value that is always stored as text, even when writing
+// with ValueInputUserEntered. It lets a single write mix formulas with
+// user-generated text that could otherwise be parsed as one.
+type Literal string
+
+// WriteOption configures how values are written to a sheet. Options can be
+// passed to individual writes, or set for every write with
+// Client.AddWriteOptions.
+type WriteOption func(*writeConfig)
+
+type writeConfig struct {
+	maxChunkCells int
+	maxChunkBytes int
+	concurrency   int
+	progress      func(done, total int)
+	resume        *WriteResult
+	shrinkToFit   bool
+	valueInput    ValueInputOption
+	insertData    InsertDataOption
+	anchor        *CellRange
+}
+
+// ChunkLimits sets the maximum number of cells and of bytes (as encoded in
+// the request) sent in a single write request. Larger writes are split in
+// chunks of rows. A limit of 0 or less keeps the default.
+func ChunkLimits(maxCells, maxBytes int) WriteOption {
+	return func(c *writeConfig) {
+		if maxCells > 0 {
+			c.maxChunkCells = maxCells
+		}
+		if maxBytes > 0 {
+			c.maxChunkBytes = maxBytes
+		}
+	}
+}
+
+// Concurrency sets how many chunks of a large write are sent in parallel.
+func Concurrency(n int) WriteOption {
+	return func(c *writeConfig) {
+		if n < 1 {
+			n = 1
+		}
+		c.concurrency = n
+	}
+}
+
+// OnProgress registers a callback invoked after each chunk of a write
+// succeeds, with the number of chunks written so far and the total.
+func OnProgress(f func(done, total int)) WriteOption {
+	return func(c *writeConfig) {
+		c.progress = f
+	}
+}
+
+// ResumeFrom skips the chunks that already succeeded in a previous write of
+// the same data with the same chunk limits.
+func ResumeFrom(prev *WriteResult) WriteOption {
+	return func(c *writeConfig) {
+		c.resume = prev
+	}
+}
+
+// ValueInput sets how written values are interpreted. Writes default to
+// ValueInputUserEntered.
+func ValueInput(opt ValueInputOption) WriteOption {
+	return func(c *writeConfig) {
+		c.valueInput = opt
+	}
+}
+
+// InsertData sets how appended values are added to the table. Appends
+// default to Overwrite.
+func InsertData(opt InsertDataOption) WriteOption {
+	return func(c *writeConfig) {
+		c.insertData = opt
+	}
+}
+
+// AppendAnchor sets the range used to find the table that values are
+// appended to. By default the whole sheet is searched.
+func AppendAnchor(r CellRange) WriteOption {
+	return func(c *writeConfig) {
+		c.anchor = &r
+	}
+}
+
+// ShrinkToFit makes writes also shrink the sheet's grid so that it ends
+// exactly at the bottom right corner of the written data. By default the grid
+// is only grown when the data doesn't fit.
+func ShrinkToFit() WriteOption {
+	return func(c *writeConfig) {
+		c.shrinkToFit = true
+	}
+}
+
+func (c *Client) AddWriteOptions(opts ...WriteOption) {
+	c.writeOptions = append(c.writeOptions, opts...)
+}
+
+func (c *Client) writeConfig(opts ...WriteOption) *writeConfig {
+	cfg := &writeConfig{
+		maxChunkCells: defaultChunkMaxCells,
+		maxChunkBytes: defaultChunkMaxBytes,
+		concurrency:   1,
+		valueInput:    ValueInputUserEntered,
+		insertData:    Overwrite,
+	}
+	for _, opt := range c.writeOptions {
+		opt(cfg)
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WriteChunk is a part of a write, covering a contiguous set of rows.
+type WriteChunk struct {
+	Range CellRange
+
+	Done bool
+	Err  error
+}
+
+// WriteResult describes which chunks of a write succeeded. A failed write
+// can be resumed by passing its result to ResumeFrom.
+type WriteResult struct {
+	Chunks []*WriteChunk
+}
+
+// Complete reports whether every chunk was written.
+func (r *WriteResult) Complete() bool {
+	for _, c := range r.Chunks {
+		if !c.Done {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Pending returns the chunks that haven't been written.
+func (r *WriteResult) Pending() []*WriteChunk {
+	var pending []*WriteChunk
+	for _, c := range r.Chunks {
+		if !c.Done {
+			pending = append(pending, c)
+		}
+	}
+
+	return pending
+}
+
+// UpdateFromPositionChunked writes data starting at start, splitting it in
+// chunks of rows when it exceeds the configured chunk limits. The returned
+// result is always non-nil when data isn't empty, even on error, so that the
+// write can be resumed.
+func (s *Sheet) UpdateFromPositionChunked(data [][]interface{}, start CellPos, opts ...WriteOption) (*WriteResult, error) {
+	if len(data) == 0 {
+		return &WriteResult{}, nil
+	}
+
+	cfg := s.Client.writeConfig(opts...)
+	data = escapeValues(data, cfg.valueInput)
+
+	spans, err := chunkRows(data, cfg.maxChunkCells, cfg.maxChunkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WriteResult{}
+	for _, span := range spans {
+		chunkStart := CellPos{Row: start.Row + span.start, Col: start.Col}
+		result.Chunks = append(result.Chunks, &WriteChunk{
+			Range: dataRange(chunkStart, data[span.start:span.end]),
+		})
+	}
+
+	if err := s.fitGrid(dataRange(start, data).End, cfg.shrinkToFit); err != nil {
+		return nil, err
+	}
+
+	if cfg.resume != nil {
+		if len(cfg.resume.Chunks) != len(result.Chunks) {
+			return nil, errors.New("resumed write doesn't match the data being written")
+		}
+		for i, prev := range cfg.resume.Chunks {
+			if prev.Range != result.Chunks[i].Range {
+				return nil, errors.New("resumed write doesn't match the data being written")
+			}
+			result.Chunks[i].Done = prev.Done
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failed   error
+		finished int
+		sem      = make(chan struct{}, cfg.concurrency)
+	)
+	for _, c := range result.Chunks {
+		if c.Done {
+			finished++
+		}
+	}
+
+	for i, chunk := range result.Chunks {
+		if chunk.Done {
+			continue
+		}
+
+		mu.Lock()
+		stop := failed != nil
+		mu.Unlock()
+		if stop {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(chunk *WriteChunk, rows [][]interface{}) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := s.updateRange(chunk.Range, rows, cfg.valueInput)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				chunk.Err = err
+				if failed == nil {
+					failed = errors.Wrapf(err, "couldn't write %s", chunk.Range.String())
+				}
+				return
+			}
+
+			chunk.Done = true
+			finished++
+			if cfg.progress != nil {
+				cfg.progress(finished, len(result.Chunks))
+			}
+		}(chunk, data[spans[i].start:spans[i].end])
+	}
+	wg.Wait()
+
+	return result, failed
+}
+
+func (s *Sheet) updateRange(cellRange CellRange, data [][]interface{}, valueInput ValueInputOption) error {
+	sheetRange := fmt.Sprintf("%s!%s", quoteSheetName(s.Title()), cellRange.String())
+
+	vRange := &sheets.ValueRange{
+		Range:  sheetRange,
+		Values: data,
+	}
+
+	req := s.Client.Sheets.Spreadsheets.Values.Update(s.Spreadsheet.Id(), sheetRange, vRange)
+	req.ValueInputOption(string(valueInput))
+
+	return googleRetry(func() error {
+		_, err := req.Do(s.Client.options...)
+		return err
+	})
+}
+
+// dataRange returns the range covered by data written at start. Unlike
+// RangeForData it's as wide as the widest row, and always covers at least the
+// start column so that blank rows still make a valid range.
+func dataRange(start CellPos, data [][]interface{}) CellRange {
+	width := 1
+	for _, row := range data {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	return CellRange{Start: start, End: CellPos{start.Row + len(data) - 1, start.Col + width - 1}}
+}
+
+// escapeValues returns data with Literal values escaped so that they're not
+// parsed when written with valueInput. data is only copied if it contains
+// literals.
+func escapeValues(data [][]interface{}, valueInput ValueInputOption) [][]interface{} {
+	if valueInput != ValueInputUserEntered {
+		return data
+	}
+
+	escaped := data
+	for i, row := range data {
+		copied := false
+		for j, value := range row {
+			literal, ok := value.(Literal)
+			if !ok {
+				continue
+			}
+
+			if !copied {
+				if &escaped[0] == &data[0] {
+					escaped = append([][]interface{}(nil), data...)
+				}
+				escaped[i] = append([]interface{}(nil), row...)
+				copied = true
+			}
+
+			// A leading apostrophe forces the value to be stored as text
+			escaped[i][j] = "'" + string(literal)
+		}
+	}
+
+	return escaped
+}
+
+type rowSpan struct {
+	start int
+	end   int
+}
+
+// chunkRows splits data in consecutive spans of rows that stay under maxCells
+// cells and maxBytes of JSON. A row that is larger than the limits on its own
+// gets a span to itself.
+func chunkRows(data [][]interface{}, maxCells, maxBytes int) ([]rowSpan, error) {
+	var (
+		spans []rowSpan
+		cur   rowSpan
+		cells int
+		size  int
+	)
+
+	for i, row := range data {
+		encoded, err := json.Marshal(row)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't encode row %d", i)
+		}
+		// Account for the separating comma
+		rowSize := len(encoded) + 1
+
+		if cur.end > cur.start && (cells+len(row) > maxCells || size+rowSize > maxBytes) {
+			spans = append(spans, cur)
+			cur = rowSpan{start: i, end: i}
+			cells, size = 0, 0
+		}
+
+		cur.end = i + 1
+		cells += len(row)
+		size += rowSize
+	}
+
+	if cur.end > cur.start {
+		spans = append(spans, cur)
+	}
+
+	return spans, nil
+}
+
+// fitGrid resizes the sheet so that its grid reaches end, shrinking it to end
+// exactly when shrink is set.
+func (s *Sheet) fitGrid(end CellPos, shrink bool) error {
+	// The cached grid size may be out of date
+	if err := s.Spreadsheet.refreshIfStale(); err != nil {
+		return err
+	}
+	s.refresh()
+
+	requests := s.resizeRequests(end, shrink)
+	if len(requests) == 0 {
+		return nil
+	}
+
+	_, err := s.doBatch(requests...)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't resize sheet %s", s.Title())
+	}
+
+	return nil
+}
+
+func (s *Sheet) resizeRequests(end CellPos, shrink bool) []*sheets.Request {
+	if s.Properties == nil || s.Properties.GridProperties == nil {
+		return nil
+	}
+	grid := s.Properties.GridProperties
+
+	rows, cols := int64(end.Row+1), int64(end.Col+1)
+
+	if shrink {
+		if rows == grid.RowCount && cols == grid.ColumnCount {
+			return nil
+		}
+
+		return []*sheets.Request{{
+			UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+				Properties: &sheets.SheetProperties{
+					SheetId: s.Properties.SheetId,
+					GridProperties: &sheets.GridProperties{
+						RowCount:    rows,
+						ColumnCount: cols,
+					},
+				},
+				Fields: "gridProperties.rowCount,gridProperties.columnCount",
+			},
+		}}
+	}
+
+	var requests []*sheets.Request
+	if rows > grid.RowCount {
+		requests = append(requests, &sheets.Request{
+			AppendDimension: &sheets.AppendDimensionRequest{
+				SheetId:   s.Properties.SheetId,
+				Dimension: "ROWS",
+				Length:    rows - grid.RowCount,
+			},
+		})
+	}
+	if cols > grid.ColumnCount {
+		requests = append(requests, &sheets.Request{
+			AppendDimension: &sheets.AppendDimensionRequest{
+				SheetId:   s.Properties.SheetId,
+				Dimension: "COLUMNS",
+				Length:    cols - grid.ColumnCount,
+			},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,214 @@
+package sheets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func testRows(n, width int) [][]interface{} {
+	data := make([][]interface{}, n)
+	for i := range data {
+		row := make([]interface{}, width)
+		for j := range row {
+			row[j] = "x"
+		}
+		data[i] = row
+	}
+
+	return data
+}
+
+var chunkTests = []struct {
+	rows     int
+	width    int
+	maxCells int
+	maxBytes int
+	expected []rowSpan
+}{
+	{1, 1, 10, 1000, []rowSpan{{0, 1}}},
+	{5, 2, 4, 1000, []rowSpan{{0, 2}, {2, 4}, {4, 5}}},
+	{3, 2, 100, 1000, []rowSpan{{0, 3}}},
+	// Each ["x","x"] row is 9 bytes plus a separator
+	{3, 2, 100, 20, []rowSpan{{0, 2}, {2, 3}}},
+	// Rows larger than the limits get their own chunk
+	{2, 5, 2, 1000, []rowSpan{{0, 1}, {1, 2}}},
+}
+
+func TestChunkRows(t *testing.T) {
+	for _, tt := range chunkTests {
+		got, err := chunkRows(testRows(tt.rows, tt.width), tt.maxCells, tt.maxBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Wanted %v, but got %v for %+v", tt.expected, got, tt)
+		}
+	}
+}
+
+func TestUpdateFromPositionChunked(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		written []string
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rng := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		mu.Lock()
+		written = append(written, rng)
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(&sheets.UpdateValuesResponse{UpdatedRange: rng})
+	}))
+
+	ss := &Spreadsheet{client, &sheets.Spreadsheet{SpreadsheetId: "ssid"}}
+	sheet := &Sheet{&sheets.Sheet{Properties: &sheets.SheetProperties{Title: "Data"}}, ss, client}
+
+	var progress []int
+	res, err := sheet.UpdateFromPositionChunked(testRows(5, 2), CellPos{1, 1},
+		ChunkLimits(4, 0),
+		OnProgress(func(done, total int) {
+			if total != 3 {
+				t.Errorf("Wanted 3 chunks, but got %d", total)
+			}
+			progress = append(progress, done)
+		}),
+		ResumeFrom(&WriteResult{Chunks: []*WriteChunk{
+			{Range: CellRange{CellPos{1, 1}, CellPos{2, 2}}, Done: true},
+			{Range: CellRange{CellPos{3, 1}, CellPos{4, 2}}},
+			{Range: CellRange{CellPos{5, 1}, CellPos{5, 2}}},
+		}}),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !res.Complete() {
+		t.Errorf("Wanted a complete write, but got pending chunks %v", res.Pending())
+	}
+
+	wantWritten := []string{"'Data'!B4:C5", "'Data'!B6:C6"}
+	if !reflect.DeepEqual(written, wantWritten) {
+		t.Errorf("Wanted writes %v, but got %v", wantWritten, written)
+	}
+
+	wantProgress := []int{2, 3}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("Wanted progress %v, but got %v", wantProgress, progress)
+	}
+}
+
+func TestUpdateFromPositionChunkedRaggedRows(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		written []string
+	)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rng := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		mu.Lock()
+		written = append(written, rng)
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(&sheets.UpdateValuesResponse{UpdatedRange: rng})
+	}))
+
+	ss := &Spreadsheet{client, &sheets.Spreadsheet{SpreadsheetId: "ssid"}}
+	sheet := &Sheet{&sheets.Sheet{Properties: &sheets.SheetProperties{Title: "Data"}}, ss, client}
+
+	data := [][]interface{}{{"a", "b"}, {"c", "d"}, {}, {"e", "f"}, {"g"}, {"h", "i", "j"}}
+	res, err := sheet.UpdateFromPositionChunked(data, CellPos{0, 0}, ChunkLimits(100, 20), Concurrency(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var gotRanges []string
+	for _, chunk := range res.Chunks {
+		gotRanges = append(gotRanges, chunk.Range.String())
+	}
+	wantRanges := []string{"A1:B2", "A3:B5", "A6:C6"}
+	if !reflect.DeepEqual(gotRanges, wantRanges) {
+		t.Errorf("Wanted chunks %v, but got %v", wantRanges, gotRanges)
+	}
+
+	wantWritten := []string{"'Data'!A1:B2", "'Data'!A3:B5", "'Data'!A6:C6"}
+	if !reflect.DeepEqual(written, wantWritten) {
+		t.Errorf("Wanted writes %v, but got %v", wantWritten, written)
+	}
+}
+
+var resizeTests = []struct {
+	end      CellPos
+	shrink   bool
+	expected []string
+}{
+	{CellPos{999, 25}, false, nil},
+	{CellPos{10, 3}, false, nil},
+	{CellPos{1000, 25}, false, []string{"ROWS+1"}},
+	{CellPos{1999, 27}, false, []string{"ROWS+1000", "COLUMNS+2"}},
+	{CellPos{999, 25}, true, nil},
+	{CellPos{10, 3}, true, []string{"11x4"}},
+	{CellPos{1999, 3}, true, []string{"2000x4"}},
+	// Ragged rows are sized by the widest one
+	{dataRange(CellPos{0, 0}, [][]interface{}{{"a"}, {"b", "c", "d"}}).End, true, []string{"2x3"}},
+	{dataRange(CellPos{0, 25}, [][]interface{}{{}, {"a", "b", "c"}}).End, false, []string{"COLUMNS+2"}},
+}
+
+func TestResizeRequests(t *testing.T) {
+	sheet := &Sheet{Sheet: &sheets.Sheet{Properties: &sheets.SheetProperties{
+		SheetId:        42,
+		GridProperties: &sheets.GridProperties{RowCount: 1000, ColumnCount: 26},
+	}}}
+
+	for _, tt := range resizeTests {
+		var got []string
+		for _, req := range sheet.resizeRequests(tt.end, tt.shrink) {
+			switch {
+			case req.AppendDimension != nil:
+				if req.AppendDimension.SheetId != 42 {
+					t.Errorf("Wanted sheet 42, but got %d", req.AppendDimension.SheetId)
+				}
+				got = append(got, fmt.Sprintf("%s+%d", req.AppendDimension.Dimension, req.AppendDimension.Length))
+			case req.UpdateSheetProperties != nil:
+				grid := req.UpdateSheetProperties.Properties.GridProperties
+				got = append(got, fmt.Sprintf("%dx%d", grid.RowCount, grid.ColumnCount))
+			}
+		}
+
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Wanted %v, but got %v for %+v", tt.expected, got, tt)
+		}
+	}
+}
+
+func TestEscapeValues(t *testing.T) {
+	data := [][]interface{}{
+		{"=SUM(A1:A2)", Literal("=not a formula"), 3},
+		{"00123", Literal("00123")},
+	}
+
+	got := escapeValues(data, ValueInputUserEntered)
+	expected := [][]interface{}{
+		{"=SUM(A1:A2)", "'=not a formula", 3},
+		{"00123", "'00123"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Wanted %v, but got %v", expected, got)
+	}
+
+	if data[0][1] != Literal("=not a formula") {
+		t.Errorf("Escaping modified the original data: %v", data)
+	}
+
+	if got := escapeValues(data, ValueInputRaw); !reflect.DeepEqual(got, data) {
+		t.Errorf("Wanted raw values to be left as-is, but got %v", got)
+	}
+}